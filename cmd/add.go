@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagFirst      = "first"
+	flagLast       = "last"
+	flagHireDate   = "hire-date"
+	flagEmail      = "email"
+	flagSupervisor = "supervisor"
+)
+
+// addFields maps the flags of the add command to the fields they set.
+var addFields = map[string]string{
+	flagFirst:      "firstName",
+	flagLast:       "lastName",
+	flagHireDate:   "hireDate",
+	flagEmail:      "workEmail",
+	flagTitle:      "jobTitle",
+	flagDepartment: "department",
+	flagLocation:   "location",
+}
+
+func init() {
+	addCmd.Flags().String(flagFirst, "", "First name")
+	addCmd.Flags().String(flagLast, "", "Last name")
+	addCmd.Flags().String(flagHireDate, "", "Hire date (YYYY-MM-DD)")
+	addCmd.Flags().String(flagEmail, "", "Work email")
+	addCmd.Flags().String(flagTitle, "", "Job title")
+	addCmd.Flags().String(flagDepartment, "", "Department")
+	addCmd.Flags().String(flagLocation, "", "Location")
+	addCmd.Flags().String(flagSupervisor, "", "Supervisor's name or work email, as in the directory")
+	addCmd.Flags().Bool(flagYes, false, "Create without asking for confirmation")
+	addCmd.MarkFlagRequired(flagFirst)
+	addCmd.MarkFlagRequired(flagLast)
+	employeeCmd.AddCommand(addCmd)
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Create a new employee",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.AddCmd{Fields: make(map[string]string)}
+
+		for flag, field := range addFields {
+			value, err := flags.GetString(flag)
+			if err != nil {
+				return err
+			}
+			c.Fields[field] = value
+		}
+		var err error
+		c.Supervisor, err = flags.GetString(flagSupervisor)
+		if err != nil {
+			return err
+		}
+		c.Yes, err = flags.GetBool(flagYes)
+		if err != nil {
+			return err
+		}
+
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagFile        = "file"
+	flagResults     = "results"
+	flagDryRun      = "dry-run"
+	flagConcurrency = "concurrency"
+)
+
+func init() {
+	bulkUpdateCmd.Flags().String(flagFile, "", "CSV of changes with an id, email or name column and a column per field")
+	bulkUpdateCmd.Flags().String(flagResults, "", "Where to write the per-row results CSV (default <file>-results.csv)")
+	bulkUpdateCmd.Flags().Bool(flagDryRun, false, "Show the changes without applying them")
+	bulkUpdateCmd.Flags().Bool(flagYes, false, "Apply changes without asking for confirmation")
+	bulkUpdateCmd.Flags().Int(flagConcurrency, 4, "Maximum number of concurrent requests")
+	bulkUpdateCmd.MarkFlagRequired(flagFile)
+	bulkCmd.AddCommand(bulkUpdateCmd)
+	rootCmd.AddCommand(bulkCmd)
+}
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Make changes to many employees at once",
+}
+
+var bulkUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update employee fields from a CSV file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.BulkUpdateCmd{}
+
+		var err error
+		c.File, err = flags.GetString(flagFile)
+		if err != nil {
+			return err
+		}
+		c.Results, err = flags.GetString(flagResults)
+		if err != nil {
+			return err
+		}
+		if c.Results == "" {
+			c.Results = strings.TrimSuffix(c.File, filepath.Ext(c.File)) + "-results.csv"
+		}
+		c.DryRun, err = flags.GetBool(flagDryRun)
+		if err != nil {
+			return err
+		}
+		c.Yes, err = flags.GetBool(flagYes)
+		if err != nil {
+			return err
+		}
+		c.Concurrency, err = flags.GetInt(flagConcurrency)
+		if err != nil {
+			return err
+		}
+
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
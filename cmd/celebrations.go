@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagDays = "days"
+	flagTeam = "team"
+)
+
+func init() {
+	celebrationsCmd.Flags().Int(flagDays, 14, "Number of days ahead to look")
+	celebrationsCmd.Flags().String(flagTeam, "", "Only include this manager and everyone reporting to them (case insensitive regex)")
+	rootCmd.AddCommand(celebrationsCmd)
+}
+
+var celebrationsCmd = &cobra.Command{
+	Use:   "celebrations",
+	Short: "List upcoming birthdays and work anniversaries",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.CelebrationsCmd{}
+
+		var err error
+		c.Days, err = flags.GetInt(flagDays)
+		if err != nil {
+			return err
+		}
+		team, err := flags.GetString(flagTeam)
+		if err != nil {
+			return err
+		}
+		c.Team, err = nameFlag(team)
+		if err != nil {
+			return err
+		}
+
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
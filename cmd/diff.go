@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagSince = "since"
+)
+
+func init() {
+	diffCmd.Flags().String(flagSince, "", "Compare the current directory with the stored snapshot from this long ago, e.g. 7d")
+	diffCmd.Flags().String(flagFormat, "text", "Output format: text or json")
+	rootCmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [old.json [new.json]]",
+	Short: "Show joiners, leavers and changes between two directory snapshots",
+	Long: `Show joiners, leavers, title changes, department moves and manager changes
+between two directory snapshots. With one snapshot, or with --since, the
+current directory is compared against it.`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.DiffCmd{}
+
+		var err error
+		c.Since, err = flags.GetString(flagSince)
+		if err != nil {
+			return err
+		}
+		switch {
+		case c.Since != "" && len(args) > 0:
+			return errors.New("give either --since or snapshot files, not both")
+		case c.Since != "":
+			if c.Store, err = bhr.DefaultSnapshotStore(); err != nil {
+				return err
+			}
+		case len(args) == 0:
+			return errors.New("give snapshot files or --since")
+		case len(args) == 2:
+			c.New = args[1]
+			fallthrough
+		default:
+			c.Old = args[0]
+		}
+		c.Format, err = flags.GetString(flagFormat)
+		if err != nil {
+			return err
+		}
+
+		if c.New == "" {
+			c.Client, err = newClient()
+			if err != nil {
+				return err
+			}
+		}
+		return c.Run()
+	},
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"errors"
-	"os"
 	"regexp"
 
 	"github.com/shric/bhr/pkg/bhr"
@@ -28,14 +26,8 @@ var directoryCmd = &cobra.Command{
 		flags := cmd.Flags()
 		c := bhr.DirectoryCmd{}
 
-		var apiKey string
-		var ok bool
 		var err error
 
-		if apiKey, ok = os.LookupEnv("BAMBOOHR_API_KEY"); !ok {
-			return errors.New("BAMBOOHR_API_KEY not set")
-		}
-
 		c.Department, err = flags.GetString(flagDepartment)
 		if err != nil {
 			return err
@@ -56,7 +48,10 @@ var directoryCmd = &cobra.Command{
 			return err
 		}
 
-		c.Client = bhr.NewClient(apiKey)
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
 		return c.Run()
 	},
 }
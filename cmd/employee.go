@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"errors"
-	"os"
 	"regexp"
 	"strings"
 
@@ -11,15 +9,21 @@ import (
 )
 
 const (
-	flagName  = "name"
-	flagID    = "id"
-	flagImage = "image"
+	flagName          = "name"
+	flagID            = "id"
+	flagImage         = "image"
+	flagImageProtocol = "image-protocol"
+	flagImageWidth    = "image-width"
+	flagFields        = "fields"
 )
 
 func init() {
 	employeeCmd.PersistentFlags().String(flagName, "", "Name of employee (API key owner if unspecified)")
 	employeeCmd.PersistentFlags().Int(flagID, -1, "ID of employee")
-	employeeCmd.PersistentFlags().Bool(flagImage, false, "Display profile image using sixel")
+	employeeCmd.PersistentFlags().Bool(flagImage, false, "Display profile image, using the best protocol the terminal supports")
+	employeeCmd.PersistentFlags().String(flagImageProtocol, "auto", "Protocol to display the image with: auto, sixel, kitty, iterm, blocks or ascii (implies --image)")
+	employeeCmd.PersistentFlags().Int(flagImageWidth, bhr.DefaultImageWidth, "Width of the image in terminal cells")
+	employeeCmd.PersistentFlags().StringSlice(flagFields, nil, "Show only these fields (aliases, IDs or names, see bhr fields)")
 	rootCmd.AddCommand(employeeCmd)
 }
 
@@ -31,13 +35,6 @@ var employeeCmd = &cobra.Command{
 		flags := cmd.Flags()
 		c := bhr.EmployeeCmd{}
 
-		var apiKey string
-		var ok bool
-
-		if apiKey, ok = os.LookupEnv("BAMBOOHR_API_KEY"); !ok {
-			return errors.New("BAMBOOHR_API_KEY not set")
-		}
-
 		result, err := flags.GetString(flagName)
 		if err != nil {
 			return err
@@ -56,12 +53,46 @@ var employeeCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		protocol, err := flags.GetString(flagImageProtocol)
+		if err != nil {
+			return err
+		}
+		c.ImageProtocol, err = bhr.ParseImageProtocol(protocol)
+		if err != nil {
+			return err
+		}
+		c.ImageWidth, err = flags.GetInt(flagImageWidth)
+		if err != nil {
+			return err
+		}
+		if flags.Changed(flagImageProtocol) || flags.Changed(flagImageWidth) {
+			c.Image = true
+		}
+
+		c.Fields, err = flags.GetStringSlice(flagFields)
+		if err != nil {
+			return err
+		}
 
-		c.Client = bhr.NewClient(apiKey)
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
 		return c.Run()
 	},
 }
 
+// regexFlag returns a flag value as a case insensitive regex, checking it
+// compiles.
+func regexFlag(flag string, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	flag = "(?i)" + flag
+	_, err = regexp.Compile(flag)
+	return flag, err
+}
+
 func nameFlag(flag string) (string, error) {
 	if flag != "" {
 		flag = strings.Replace(flag, " ", ".*", -1)
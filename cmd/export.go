@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagPhotos = "photos"
+	flagBaseDN = "base-dn"
+)
+
+func init() {
+	exportCmd.PersistentFlags().StringP(flagOutput, "o", "", "File to export to (default stdout)")
+	exportCmd.PersistentFlags().Bool(flagPhotos, true, "Embed employee photos")
+	exportCmd.PersistentFlags().String(flagDepartment, "", "Filter by department (case insensitive regex)")
+	exportCmd.PersistentFlags().String(flagTitle, "", "Filter by title (case insensitive regex)")
+	exportCmd.PersistentFlags().Int(flagConcurrency, 4, "Maximum number of concurrent photo requests")
+	exportLDIFCmd.Flags().String(flagBaseDN, bhr.DefaultBaseDN, "DN to export entries below")
+	exportCmd.AddCommand(exportVCardCmd, exportLDIFCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the directory as contacts",
+}
+
+var exportVCardCmd = &cobra.Command{
+	Use:   "vcard",
+	Short: "Export employees as vCards",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runExport(cmd, "vcard")
+	},
+}
+
+var exportLDIFCmd = &cobra.Command{
+	Use:   "ldif",
+	Short: "Export employees as LDIF inetOrgPerson entries",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runExport(cmd, "ldif")
+	},
+}
+
+func runExport(cmd *cobra.Command, format string) error {
+	flags := cmd.Flags()
+	c := bhr.ExportCmd{Format: format, BaseDN: bhr.DefaultBaseDN}
+
+	var err error
+	c.Output, err = flags.GetString(flagOutput)
+	if err != nil {
+		return err
+	}
+	c.Photos, err = flags.GetBool(flagPhotos)
+	if err != nil {
+		return err
+	}
+	if format == "ldif" {
+		c.BaseDN, err = flags.GetString(flagBaseDN)
+		if err != nil {
+			return err
+		}
+	}
+	c.Department, err = regexFlag(flags.GetString(flagDepartment))
+	if err != nil {
+		return err
+	}
+	c.Title, err = regexFlag(flags.GetString(flagTitle))
+	if err != nil {
+		return err
+	}
+	c.Concurrency, err = flags.GetInt(flagConcurrency)
+	if err != nil {
+		return err
+	}
+
+	c.Client, err = newClient()
+	if err != nil {
+		return err
+	}
+	return c.Run()
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagFilter = "filter"
+)
+
+func init() {
+	fieldsCmd.PersistentFlags().String(flagFilter, "", "Filter by field alias or name (case insensitive regex)")
+	rootCmd.AddCommand(fieldsCmd)
+}
+
+var fieldsCmd = &cobra.Command{
+	Use:   "fields",
+	Short: "List standard and custom employee fields",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.FieldsCmd{}
+
+		var err error
+		c.Filter, err = regexFlag(flags.GetString(flagFilter))
+		if err != nil {
+			return err
+		}
+
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+func init() {
+	historyCmd.PersistentFlags().String(flagName, "", "Name of employee (API key owner if unspecified)")
+	historyCmd.PersistentFlags().Int(flagID, -1, "ID of employee")
+	rootCmd.AddCommand(historyCmd)
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the job history timeline of an employee",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.HistoryCmd{}
+
+		result, err := flags.GetString(flagName)
+		if err != nil {
+			return err
+		}
+		c.Name, err = nameFlag(result)
+		if err != nil {
+			return err
+		}
+		c.ID, err = flags.GetInt(flagID)
+		if err != nil {
+			return err
+		}
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagLocation = "location"
+	flagStart    = "start"
+	flagEnd      = "end"
+	flagMapping  = "holiday-locations"
+)
+
+func init() {
+	holidaysCmd.PersistentFlags().String(flagLocation, "", "Filter by location (case insensitive regex)")
+	holidaysCmd.PersistentFlags().String(flagStart, "", "First date to list (YYYY-MM-DD, default today)")
+	holidaysCmd.PersistentFlags().String(flagEnd, "", "Last date to list (YYYY-MM-DD, default a year after start)")
+	holidaysCmd.PersistentFlags().String(flagMapping, "", "JSON file mapping holiday names to the locations they apply to")
+	rootCmd.AddCommand(holidaysCmd)
+}
+
+var holidaysCmd = &cobra.Command{
+	Use:   "holidays",
+	Short: "List company holidays and the locations they apply to",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.HolidaysCmd{}
+
+		var err error
+		c.Location, err = regexFlag(flags.GetString(flagLocation))
+		if err != nil {
+			return err
+		}
+
+		c.Start, err = dateFlag(flags.GetString(flagStart))
+		if err != nil {
+			return err
+		}
+		if c.Start.IsZero() {
+			c.Start = time.Now()
+		}
+		c.End, err = dateFlag(flags.GetString(flagEnd))
+		if err != nil {
+			return err
+		}
+		if c.End.IsZero() {
+			c.End = c.Start.AddDate(1, 0, 0)
+		}
+
+		var opts []bhr.Option
+		mapping, err := flags.GetString(flagMapping)
+		if err != nil {
+			return err
+		}
+		if mapping != "" {
+			m, err := bhr.LoadHolidayLocations(mapping)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, bhr.WithHolidayLocations(m))
+		}
+
+		c.Client, err = newClient(opts...)
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
+
+// dateFlag parses a YYYY-MM-DD flag value as a local date, like the dates in
+// employee records, returning the zero time if the flag is empty.
+func dateFlag(flag string, err error) (time.Time, error) {
+	if err != nil || flag == "" {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation("2006-01-02", flag, time.Local)
+}
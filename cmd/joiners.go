@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagUntil     = "until"
+	flagByManager = "by-manager"
+)
+
+func init() {
+	for _, c := range []*cobra.Command{joinersCmd, leaversCmd} {
+		c.Flags().String(flagSince, "", "Earliest date (YYYY-MM-DD or e.g. 30d, default the start of this month)")
+		c.Flags().String(flagUntil, "", "Latest date (YYYY-MM-DD, default today)")
+		c.Flags().String(flagDepartment, "", "Filter by department (case insensitive regex)")
+		c.Flags().String(flagTitle, "", "Filter by title (case insensitive regex)")
+		c.Flags().Bool(flagByManager, false, "Group by manager")
+		c.Flags().Bool(flagChart, false, "Draw charts by month and department")
+		rootCmd.AddCommand(c)
+	}
+}
+
+var joinersCmd = &cobra.Command{
+	Use:   "joiners",
+	Short: "List employees hired in a period",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMovements(cmd, false)
+	},
+}
+
+var leaversCmd = &cobra.Command{
+	Use:   "leavers",
+	Short: "List employees terminated in a period",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMovements(cmd, true)
+	},
+}
+
+func runMovements(cmd *cobra.Command, leavers bool) error {
+	flags := cmd.Flags()
+	c := bhr.MovementsCmd{Leavers: leavers}
+
+	now := time.Now()
+	since, err := flags.GetString(flagSince)
+	if err != nil {
+		return err
+	}
+	if since == "" {
+		c.Since = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	} else if c.Since, err = bhr.ParseSince(since, now); err != nil {
+		return err
+	}
+	c.Until, err = dateFlag(flags.GetString(flagUntil))
+	if err != nil {
+		return err
+	}
+	if c.Until.IsZero() {
+		c.Until = now
+	}
+
+	c.Department, err = regexFlag(flags.GetString(flagDepartment))
+	if err != nil {
+		return err
+	}
+	c.Title, err = regexFlag(flags.GetString(flagTitle))
+	if err != nil {
+		return err
+	}
+	c.ByManager, err = flags.GetBool(flagByManager)
+	if err != nil {
+		return err
+	}
+	c.Chart, err = flags.GetBool(flagChart)
+	if err != nil {
+		return err
+	}
+
+	c.Client, err = newClient()
+	if err != nil {
+		return err
+	}
+	return c.Run()
+}
@@ -1,9 +1,6 @@
 package cmd
 
 import (
-	"errors"
-	"os"
-
 	"github.com/spf13/cobra"
 
 	"github.com/shric/bhr/pkg/bhr"
@@ -22,12 +19,6 @@ var percentCmd = &cobra.Command{
 		flags := cmd.Flags()
 		c := bhr.PercentCmd{}
 
-		var apiKey string
-		var ok bool
-
-		if apiKey, ok = os.LookupEnv("BAMBOOHR_API_KEY"); !ok {
-			return errors.New("BAMBOOHR_API_KEY not set")
-		}
 		result, err := flags.GetString(flagName)
 		if err != nil {
 			return err
@@ -40,7 +31,10 @@ var percentCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		c.Client = bhr.NewClient(apiKey)
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
 		return c.Run()
 	},
 }
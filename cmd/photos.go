@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagSize   = "size"
+	flagNameBy = "name-by"
+	flagCrop   = "crop"
+)
+
+func init() {
+	photosDownloadCmd.Flags().StringP(flagOutput, "o", "photos", "Directory to save photos in")
+	photosDownloadCmd.Flags().String(flagSize, "large", "Photo size: "+strings.Join(bhr.PhotoSizes, ", "))
+	photosDownloadCmd.Flags().String(flagNameBy, "id", "Name files by employee id or email")
+	photosDownloadCmd.Flags().String(flagDepartment, "", "Filter by department (case insensitive regex)")
+	photosDownloadCmd.Flags().String(flagTitle, "", "Filter by title (case insensitive regex)")
+	photosDownloadCmd.Flags().Int(flagConcurrency, 4, "Maximum number of concurrent requests")
+	photosCmd.AddCommand(photosDownloadCmd)
+
+	photosUploadCmd.Flags().String(flagName, "", "Name of employee (API key owner if unspecified)")
+	photosUploadCmd.Flags().Int(flagID, -1, "ID of employee")
+	photosUploadCmd.Flags().Bool(flagCrop, true, "Crop photos that aren't square to their centre instead of refusing them")
+	photosUploadCmd.Flags().Bool(flagYes, false, "Upload without asking for confirmation")
+	photosCmd.AddCommand(photosUploadCmd)
+	rootCmd.AddCommand(photosCmd)
+}
+
+var photosCmd = &cobra.Command{
+	Use:     "photos",
+	Aliases: []string{"photo"},
+	Short:   "Work with employee photos",
+}
+
+var photosUploadCmd = &cobra.Command{
+	Use:   "upload <file>",
+	Short: "Replace an employee's photo",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.PhotoUploadCmd{File: args[0]}
+
+		result, err := flags.GetString(flagName)
+		if err != nil {
+			return err
+		}
+		c.Name, err = nameFlag(result)
+		if err != nil {
+			return err
+		}
+		c.ID, err = flags.GetInt(flagID)
+		if err != nil {
+			return err
+		}
+		c.Crop, err = flags.GetBool(flagCrop)
+		if err != nil {
+			return err
+		}
+		c.Yes, err = flags.GetBool(flagYes)
+		if err != nil {
+			return err
+		}
+
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
+
+var photosDownloadCmd = &cobra.Command{
+	Use:   "download",
+	Short: "Download employee photos, skipping those already up to date",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.PhotosCmd{}
+
+		var err error
+		c.Output, err = flags.GetString(flagOutput)
+		if err != nil {
+			return err
+		}
+		size, err := flags.GetString(flagSize)
+		if err != nil {
+			return err
+		}
+		c.Size, err = bhr.ParsePhotoSize(size)
+		if err != nil {
+			return err
+		}
+		c.NameBy, err = flags.GetString(flagNameBy)
+		if err != nil {
+			return err
+		}
+		if c.NameBy != "id" && c.NameBy != "email" {
+			return errors.New("--name-by must be id or email")
+		}
+		c.Department, err = regexFlag(flags.GetString(flagDepartment))
+		if err != nil {
+			return err
+		}
+		c.Title, err = regexFlag(flags.GetString(flagTitle))
+		if err != nil {
+			return err
+		}
+		c.Concurrency, err = flags.GetInt(flagConcurrency)
+		if err != nil {
+			return err
+		}
+
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
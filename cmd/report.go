@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagFormat = "format"
+)
+
+func init() {
+	reportCmd.PersistentFlags().StringArray(flagFilter, nil, "Filter employees, repeatable, e.g. lastChanged>7d or department=eng")
+	reportCmd.PersistentFlags().String(flagFormat, "table", "Output format: table, csv or json")
+	reportCmd.Flags().StringSlice(flagFields, []string{"displayName", "jobTitle", "department"}, "Fields to report (aliases, IDs or names, see bhr fields)")
+	reportCmd.AddCommand(reportRunCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report fields for all employees",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReport(cmd, 0)
+	},
+}
+
+var reportRunCmd = &cobra.Command{
+	Use:   "run <report id>",
+	Short: "Run a saved company report",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return err
+		}
+		return runReport(cmd, id)
+	},
+}
+
+func runReport(cmd *cobra.Command, reportID int) error {
+	flags := cmd.Flags()
+	c := bhr.ReportCmd{ReportID: reportID}
+
+	var err error
+	if reportID == 0 {
+		c.Fields, err = flags.GetStringSlice(flagFields)
+		if err != nil {
+			return err
+		}
+	}
+	filters, err := flags.GetStringArray(flagFilter)
+	if err != nil {
+		return err
+	}
+	for _, filter := range filters {
+		f, err := bhr.ParseReportFilter(filter)
+		if err != nil {
+			return err
+		}
+		c.Filters = append(c.Filters, f)
+	}
+	c.Format, err = flags.GetString(flagFormat)
+	if err != nil {
+		return err
+	}
+
+	c.Client, err = newClient()
+	if err != nil {
+		return err
+	}
+	return c.Run()
+}
@@ -1,9 +1,22 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagRate      = "rate"
+	flagBurst     = "burst"
+	flagRateStats = "rate-stats"
+	flagDebug     = "debug"
+	flagSnapshot  = "snapshot"
 )
 
 var (
@@ -11,10 +24,57 @@ var (
 		Use:   "bhr",
 		Short: "bhr is a command line interface for BambooHR",
 		Long:  `A command line interface for BambooHR`,
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if showRateStats && client != nil {
+				stats := client.RateLimitStats()
+				fmt.Fprintf(os.Stderr, "%d requests, %d delayed by the rate limit for %s\n",
+					stats.Requests, stats.Waits, stats.Waited)
+			}
+		},
 	}
+
+	rate          float64
+	burst         int
+	showRateStats bool
+	debug         bool
+	snapshotFile  string
+
+	// client is the most recently created client, for reporting stats.
+	client *bhr.Client
 )
 
 func init() {
+	rootCmd.PersistentFlags().Float64Var(&rate, flagRate, 5, "Maximum requests per second to BambooHR (0 for no limit)")
+	rootCmd.PersistentFlags().IntVar(&burst, flagBurst, 10, "Maximum burst of requests to BambooHR")
+	rootCmd.PersistentFlags().BoolVar(&showRateStats, flagRateStats, false, "Report time spent waiting for the rate limit")
+	rootCmd.PersistentFlags().BoolVar(&debug, flagDebug, false, "Log requests to BambooHR")
+	rootCmd.PersistentFlags().StringVar(&snapshotFile, flagSnapshot, "", "Read the directory and employees from a snapshot file instead of BambooHR")
+}
+
+// newClient returns a client configured by the global flags and extra options,
+// reading from a snapshot if one is given and from the API otherwise.
+func newClient(extra ...bhr.Option) (*bhr.Client, error) {
+	logger := logrus.New()
+	if debug {
+		logger.SetLevel(logrus.DebugLevel)
+	}
+	opts := []bhr.Option{bhr.WithRateLimit(rate, burst), bhr.WithLogger(logger)}
+
+	var apiKey string
+	if snapshotFile != "" {
+		snapshot, err := bhr.LoadSnapshot(snapshotFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, bhr.WithSnapshot(snapshot))
+	} else {
+		var ok bool
+		if apiKey, ok = os.LookupEnv("BAMBOOHR_API_KEY"); !ok {
+			return nil, errors.New("BAMBOOHR_API_KEY not set")
+		}
+	}
+	client = bhr.NewClient(apiKey, append(opts, extra...)...)
+	return client, nil
 }
 
 func Execute() {
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagYes = "yes"
+)
+
+func init() {
+	setCmd.Flags().Bool(flagYes, false, "Apply changes without asking for confirmation")
+	employeeCmd.AddCommand(setCmd)
+}
+
+var setCmd = &cobra.Command{
+	Use:   "set field=value...",
+	Short: "Update fields of an employee",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.SetCmd{Assignments: args}
+
+		result, err := flags.GetString(flagName)
+		if err != nil {
+			return err
+		}
+		c.Name, err = nameFlag(result)
+		if err != nil {
+			return err
+		}
+		c.ID, err = flags.GetInt(flagID)
+		if err != nil {
+			return err
+		}
+		c.Yes, err = flags.GetBool(flagYes)
+		if err != nil {
+			return err
+		}
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagOutput    = "output"
+	flagEmployees = "employees"
+	flagStore     = "store"
+)
+
+func init() {
+	snapshotSaveCmd.Flags().StringP(flagOutput, "o", "directory.json", "File to save the snapshot to")
+	snapshotSaveCmd.Flags().Bool(flagStore, false, "Save to the snapshot store used by bhr diff --since instead of --output")
+	snapshotSaveCmd.Flags().Bool(flagEmployees, true, "Include every employee's full record")
+	snapshotSaveCmd.Flags().Int(flagConcurrency, 4, "Maximum number of concurrent requests when fetching employees one by one")
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save the directory for offline use with --snapshot",
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save",
+	Short: "Save a snapshot of the directory and employees",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.SnapshotCmd{}
+
+		var err error
+		c.Output, err = flags.GetString(flagOutput)
+		if err != nil {
+			return err
+		}
+		store, err := flags.GetBool(flagStore)
+		if err != nil {
+			return err
+		}
+		if store {
+			if c.Store, err = bhr.DefaultSnapshotStore(); err != nil {
+				return err
+			}
+		}
+		c.Employees, err = flags.GetBool(flagEmployees)
+		if err != nil {
+			return err
+		}
+		c.Concurrency, err = flags.GetInt(flagConcurrency)
+		if err != nil {
+			return err
+		}
+		c.Progress = func(done, total int) {
+			fmt.Fprintf(os.Stderr, "\r%d/%d employees", done, total)
+			if done == total {
+				fmt.Fprintln(os.Stderr)
+			}
+		}
+
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+const (
+	flagChart = "chart"
+)
+
+func init() {
+	statsCmd.Flags().String(flagDepartment, "", "Filter by department (case insensitive regex)")
+	statsCmd.Flags().String(flagTitle, "", "Filter by title (case insensitive regex)")
+	statsCmd.Flags().String(flagFormat, "table", "Output format: table or json")
+	statsCmd.Flags().Bool(flagChart, false, "Draw charts instead of tables")
+	rootCmd.AddCommand(statsCmd)
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Report headcount, span of control, tenure and org depth",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.StatsCmd{}
+
+		var err error
+		c.Department, err = regexFlag(flags.GetString(flagDepartment))
+		if err != nil {
+			return err
+		}
+		c.Title, err = regexFlag(flags.GetString(flagTitle))
+		if err != nil {
+			return err
+		}
+		c.Format, err = flags.GetString(flagFormat)
+		if err != nil {
+			return err
+		}
+		c.Chart, err = flags.GetBool(flagChart)
+		if err != nil {
+			return err
+		}
+		if c.Chart && c.Format != "table" {
+			return errors.New("--chart can't be used with --format " + c.Format)
+		}
+
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
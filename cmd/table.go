@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+func init() {
+	tableCmd.PersistentFlags().String(flagName, "", "Name of employee (API key owner if unspecified)")
+	tableCmd.PersistentFlags().Int(flagID, -1, "ID of employee")
+	rootCmd.AddCommand(tableCmd)
+}
+
+var tableCmd = &cobra.Command{
+	Use:   "table <name>",
+	Short: "Show an employee table such as jobInfo, employmentStatus, compensation or emergencyContacts",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.TableCmd{Table: args[0]}
+
+		result, err := flags.GetString(flagName)
+		if err != nil {
+			return err
+		}
+		c.Name, err = nameFlag(result)
+		if err != nil {
+			return err
+		}
+		c.ID, err = flags.GetInt(flagID)
+		if err != nil {
+			return err
+		}
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
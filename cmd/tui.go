@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+func init() {
+	tuiCmd.Flags().String(flagDepartment, "", "Filter by department (case insensitive regex)")
+	tuiCmd.Flags().String(flagTitle, "", "Filter by title (case insensitive regex)")
+	rootCmd.AddCommand(tuiCmd)
+}
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse the directory interactively",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		c := bhr.TUICmd{}
+
+		var err error
+		c.Department, err = regexFlag(flags.GetString(flagDepartment))
+		if err != nil {
+			return err
+		}
+		c.Title, err = regexFlag(flags.GetString(flagTitle))
+		if err != nil {
+			return err
+		}
+
+		c.Client, err = newClient()
+		if err != nil {
+			return err
+		}
+		return c.Run()
+	},
+}
@@ -8,4 +8,5 @@ require (
 	github.com/sirupsen/logrus v1.2.0
 	github.com/soniakeys/quant v1.0.0 // indirect
 	github.com/spf13/cobra v1.0.0
+	golang.org/x/crypto v0.0.0-20190308221718-c2843e01d9a2
 )
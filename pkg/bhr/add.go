@@ -0,0 +1,117 @@
+package bhr
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type AddCmd struct {
+	Client     *Client
+	Fields     map[string]string
+	Supervisor string
+	Yes        bool
+}
+
+// AddEmployee creates an employee with the given fields, keyed by field key,
+// and returns their ID.
+func (c *Client) AddEmployee(fields map[string]string) (int, error) {
+	header, err := c.sendJSON("POST", c.baseURL+"/employees/", fields, nil)
+	if err != nil {
+		return 0, err
+	}
+	location := header.Get("Location")
+	id, err := strconv.Atoi(path.Base(location))
+	if err != nil {
+		return 0, errors.Errorf("unexpected Location header %q", location)
+	}
+	return id, nil
+}
+
+// AddTableRow adds a row to one of an employee's tables.
+func (c *Client) AddTableRow(employeeID int, tableName string, row map[string]string) error {
+	_, err := c.sendJSON("POST", fmt.Sprintf(c.baseURL+"/employees/%d/tables/%s", employeeID, tableName), row, nil)
+	return err
+}
+
+// FindSupervisor returns the employee whose display name or work email is
+// exactly name, ignoring case.
+func (c *Client) FindSupervisor(name string) (*Employee, error) {
+	dir := c.GetDirectory(func(Employee) bool { return true })
+	var matches []*Employee
+	for i, emp := range dir.Employees {
+		if strings.EqualFold(emp.DisplayName, name) || strings.EqualFold(emp.WorkEmail, name) {
+			matches = append(matches, &dir.Employees[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, errors.Errorf("no employee named %q in the directory", name)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, errors.Errorf("%d employees named %q in the directory", len(matches), name)
+}
+
+func (c *AddCmd) Run() error {
+	if c.Fields["firstName"] == "" || c.Fields["lastName"] == "" {
+		return errors.New("first and last name are required")
+	}
+	values := make(map[string]string)
+	for k, v := range c.Fields {
+		if v != "" {
+			values[k] = v
+		}
+	}
+	values, fields, err := c.Client.ValidateFields(values)
+	if err != nil {
+		return err
+	}
+	var supervisor *Employee
+	if c.Supervisor != "" {
+		if supervisor, err = c.Client.FindSupervisor(c.Supervisor); err != nil {
+			return err
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	var result strings.Builder
+	pad := 15
+	for _, f := range fields {
+		result.WriteString(fmt.Sprintf("%-*s%s\n", pad, f.Name+": ", values[f.Key()]))
+	}
+	if supervisor != nil {
+		result.WriteString(fmt.Sprintf("%-*s%s (%s)\n", pad, "Supervisor: ", supervisor.DisplayName, supervisor.ID))
+	}
+	fmt.Print(result.String())
+	if !c.Yes && !confirm("Create this employee?") {
+		return errors.New("aborted")
+	}
+
+	id, err := c.Client.AddEmployee(values)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created employee %d\n", id)
+	if supervisor == nil {
+		return nil
+	}
+	// The supervisor can only be set through the job information table.
+	row := map[string]string{"date": values["hireDate"], "reportsTo": supervisor.ID}
+	if row["date"] == "" {
+		row["date"] = time.Now().Format(dateFormat)
+	}
+	for _, key := range []string{"jobTitle", "department", "location"} {
+		if values[key] != "" {
+			row[key] = values[key]
+		}
+	}
+	// The employee already exists by now, so say which one to fix up by hand.
+	return errors.Wrapf(c.Client.AddTableRow(id, "jobInfo", row),
+		"created employee %d but failed to set their supervisor", id)
+}
@@ -0,0 +1,80 @@
+package bhr_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestAddEmployee(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	// The fake server gives new employees IDs from 1001.
+	id, err := s.Client().AddEmployee(map[string]string{"firstName": "Erin", "lastName": "Green"})
+	if err != nil || id != 1001 {
+		t.Errorf("AddEmployee() = %d, %v, want 1001", id, err)
+	}
+}
+
+func TestFindSupervisor(t *testing.T) {
+	dir := copyFixtures(t)
+	defer os.RemoveAll(dir)
+	addToDirectory(t, dir, map[string]interface{}{"id": "5", "displayName": "Bob Jones"})
+	s := bhrtest.NewServer(dir)
+	defer s.Close()
+	c := s.Client()
+
+	tests := []struct {
+		name, want string
+		wantErr    bool
+	}{
+		{"carol white", "3", false},
+		{"DAN@example.com", "4", false},
+		{"Bob Jones", "", true},
+		{"Nobody", "", true},
+	}
+	for _, tt := range tests {
+		emp, err := c.FindSupervisor(tt.name)
+		if (err != nil) != tt.wantErr || (err == nil && emp.ID != tt.want) {
+			t.Errorf("FindSupervisor(%q) = %+v, %v, want %s", tt.name, emp, err, tt.want)
+		}
+	}
+}
+
+func TestAddWithSupervisor(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	c := bhr.AddCmd{Client: s.Client(), Supervisor: "bob@example.com", Yes: true, Fields: map[string]string{
+		"firstName": "Erin", "lastName": "Green", "hireDate": "2026-11-02", "department": "engineering",
+	}}
+	captureStdout(t, c.Run)
+
+	var posts []bhrtest.Request
+	for _, r := range s.Requests() {
+		if r.Method == "POST" {
+			posts = append(posts, r)
+		}
+	}
+	if len(posts) != 2 || posts[0].Path != "employees" || posts[1].Path != "employees/1001/tables/jobInfo" {
+		t.Fatalf("POSTs = %+v, want the employee then their jobInfo", posts)
+	}
+	var row map[string]string
+	if err := json.Unmarshal(posts[1].Body, &row); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"date": "2026-11-02", "reportsTo": "2", "department": "Engineering"}
+	if len(row) != len(want) {
+		t.Errorf("jobInfo row %v, want %v", row, want)
+	}
+	for k, v := range want {
+		if row[k] != v {
+			t.Errorf("jobInfo row %v, want %v", row, want)
+			break
+		}
+	}
+}
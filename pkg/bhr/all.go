@@ -0,0 +1,97 @@
+package bhr
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// GetAllOptions controls how GetAllEmployees fetches employees when it has to
+// fall back to fetching them one at a time.
+type GetAllOptions struct {
+	// Concurrency is the maximum number of requests in flight. Their rate is
+	// limited by the client's rate limit, see SetRateLimit.
+	Concurrency int
+	// Progress, if set, is called after each employee is fetched.
+	Progress func(done, total int)
+}
+
+// GetAllEmployees returns the full record of every employee. It uses a single
+// custom report when possible, and otherwise fetches each employee in the
+// snapshot, or in the directory. The directory only lists active employees, so
+// complete reports whether former employees are included.
+func (c *Client) GetAllEmployees(opts GetAllOptions) (employees []*IndividualEmployee, complete bool, err error) {
+	employees, err = c.getAllEmployeesReport()
+	if err == nil {
+		if opts.Progress != nil {
+			opts.Progress(len(employees), len(employees))
+		}
+		return employees, true, nil
+	}
+
+	var ids []int
+	if c.snapshot != nil && len(c.snapshot.Employees) > 0 {
+		for key := range c.snapshot.Employees {
+			id, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, false, err
+			}
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		complete = true
+	} else {
+		c.logger.WithError(err).Warn("custom report failed, so former employees are left out")
+		for _, emp := range c.GetDirectory(func(Employee) bool { return true }).Employees {
+			id, err := strconv.Atoi(emp.ID)
+			if err != nil {
+				return nil, false, err
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	employees = make([]*IndividualEmployee, len(ids))
+	errs := make([]error, len(ids))
+	var mu sync.Mutex
+	done := 0
+	parallel(len(ids), opts.Concurrency, func(i int) {
+		employees[i], errs[i] = c.Employee(ids[i])
+		if opts.Progress != nil {
+			mu.Lock()
+			done++
+			opts.Progress(done, len(ids))
+			mu.Unlock()
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return employees, complete, nil
+}
+
+// getAllEmployeesReport fetches every employee's fields with a custom report.
+func (c *Client) getAllEmployeesReport() ([]*IndividualEmployee, error) {
+	keys, meta := c.employeeFieldKeys()
+	report, err := c.CustomReport(keys, nil)
+	if err != nil {
+		return nil, err
+	}
+	employees := make([]*IndividualEmployee, 0, len(report.Employees))
+	for _, record := range report.Employees {
+		b, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		e := &IndividualEmployee{}
+		if err := json.Unmarshal(b, e); err != nil {
+			return nil, err
+		}
+		e.Record = NewRecord(meta, record.values)
+		employees = append(employees, e)
+	}
+	return employees, nil
+}
@@ -0,0 +1,270 @@
+package bhr
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// BulkRow is one row of a bulk update and its outcome.
+type BulkRow struct {
+	Line       int
+	Key        string
+	EmployeeID int
+	Values     map[string]string
+	Changes    []Change
+	Status     string
+	Err        error
+}
+
+type BulkUpdateCmd struct {
+	Client      *Client
+	File        string
+	Results     string
+	DryRun      bool
+	Yes         bool
+	Concurrency int
+}
+
+// identityColumns are the columns that identify the employee a row applies
+// to, in order of preference, mapped to what they identify by.
+var identityColumns = []struct{ column, by string }{
+	{"id", "id"},
+	{"employeeid", "id"},
+	{"email", "email"},
+	{"workemail", "email"},
+	{"name", "name"},
+	{"displayname", "name"},
+}
+
+// parallel calls fn for 0 <= i < n using at most concurrency goroutines.
+func parallel(n, concurrency int, fn func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
+// ReadBulkRows reads a CSV of changes. One column identifies the employee
+// (id, email or name) and every other column is a field to set. Empty cells
+// leave the field unchanged.
+func ReadBulkRows(r io.Reader) ([]*BulkRow, string, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(records) < 1 {
+		return nil, "", errors.New("empty CSV")
+	}
+	header := records[0]
+
+	identity, by := -1, ""
+	for _, id := range identityColumns {
+		for i, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), id.column) {
+				identity, by = i, id.by
+				break
+			}
+		}
+		if identity != -1 {
+			break
+		}
+	}
+	if identity == -1 {
+		return nil, "", errors.New("CSV has no id, email or name column")
+	}
+
+	var rows []*BulkRow
+	for n, record := range records[1:] {
+		row := &BulkRow{Line: n + 2, Values: make(map[string]string)}
+		for i, value := range record {
+			if i == identity {
+				row.Key = strings.TrimSpace(value)
+				continue
+			}
+			if value = strings.TrimSpace(value); value != "" {
+				row.Values[strings.TrimSpace(header[i])] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, by, nil
+}
+
+// resolveBulkRows sets the employee ID of each row, looking up emails and
+// names in the directory.
+func (c *Client) resolveBulkRows(rows []*BulkRow, by string) {
+	var dir *Directory
+	if by != "id" {
+		dir = c.GetDirectory(func(Employee) bool { return true })
+	}
+	for _, row := range rows {
+		if by == "id" {
+			id, err := strconv.Atoi(row.Key)
+			row.EmployeeID, row.Err = id, errors.Wrapf(err, "invalid id %q", row.Key)
+			continue
+		}
+		var matches []Employee
+		for _, emp := range dir.Employees {
+			if by == "email" && strings.EqualFold(emp.WorkEmail, row.Key) ||
+				by == "name" && strings.EqualFold(emp.DisplayName, row.Key) {
+				matches = append(matches, emp)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			row.Err = errors.Errorf("no employee with %s %q", by, row.Key)
+		case 1:
+			row.EmployeeID, row.Err = strconv.Atoi(matches[0].ID)
+		default:
+			row.Err = errors.Errorf("%d employees with %s %q", len(matches), by, row.Key)
+		}
+	}
+}
+
+// diffBulkRow validates a row and compares it against the employee's current
+// values.
+func (c *Client) diffBulkRow(row *BulkRow) {
+	values, fields, err := c.ValidateFields(row.Values)
+	if err != nil {
+		row.Err = err
+		return
+	}
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.Key())
+	}
+	current, err := c.GetEmployeeFields(row.EmployeeID, keys)
+	if err != nil {
+		row.Err = err
+		return
+	}
+	row.Changes = Changes(fields, current, values)
+}
+
+// WriteBulkResults writes the outcome of each row as CSV.
+func WriteBulkResults(w io.Writer, rows []*BulkRow) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"line", "key", "employeeId", "status", "changes", "error"})
+	for _, row := range rows {
+		var changes []string
+		for _, change := range row.Changes {
+			changes = append(changes, fmt.Sprintf("%s=%s", change.Field.Key(), change.New))
+		}
+		var msg string
+		if row.Err != nil {
+			msg = row.Err.Error()
+		}
+		out.Write([]string{strconv.Itoa(row.Line), row.Key, strconv.Itoa(row.EmployeeID),
+			row.Status, strings.Join(changes, "; "), msg})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func (c *BulkUpdateCmd) Run() error {
+	f, err := os.Open(c.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	rows, by, err := ReadBulkRows(f)
+	if err != nil {
+		return errors.Wrap(err, c.File)
+	}
+
+	c.Client.resolveBulkRows(rows, by)
+	parallel(len(rows), c.Concurrency, func(i int) {
+		if rows[i].Err == nil {
+			c.Client.diffBulkRow(rows[i])
+		}
+	})
+
+	var result strings.Builder
+	pending := 0
+	for _, row := range rows {
+		switch {
+		case row.Err != nil:
+			row.Status = "error"
+			result.WriteString(fmt.Sprintf("line %d (%s): %v\n", row.Line, row.Key, row.Err))
+		case len(row.Changes) == 0:
+			row.Status = "unchanged"
+		default:
+			pending++
+			row.Status = "pending"
+			result.WriteString(fmt.Sprintf("line %d (%s, id %d):\n", row.Line, row.Key, row.EmployeeID))
+			var changes strings.Builder
+			RenderChanges(row.Changes, &changes)
+			for _, line := range strings.SplitAfter(strings.TrimSuffix(changes.String(), "\n"), "\n") {
+				result.WriteString("  " + line)
+			}
+			result.WriteRune('\n')
+		}
+	}
+	fmt.Print(result.String())
+	fmt.Printf("%d row(s), %d to update\n", len(rows), pending)
+
+	if c.DryRun {
+		for _, row := range rows {
+			if row.Status == "pending" {
+				row.Status = "dry-run"
+			}
+		}
+	} else if pending > 0 && (c.Yes || confirm("Apply these changes?")) {
+		parallel(len(rows), c.Concurrency, func(i int) {
+			row := rows[i]
+			if row.Status != "pending" {
+				return
+			}
+			update := make(map[string]string)
+			for _, change := range row.Changes {
+				update[change.Field.Key()] = change.New
+			}
+			if row.Err = c.Client.UpdateEmployee(row.EmployeeID, update); row.Err != nil {
+				row.Status = "error"
+			} else {
+				row.Status = "updated"
+			}
+		})
+	} else {
+		for _, row := range rows {
+			if row.Status == "pending" {
+				row.Status = "skipped"
+			}
+		}
+	}
+
+	if c.Results == "" {
+		return nil
+	}
+	out, err := os.Create(c.Results)
+	if err != nil {
+		return err
+	}
+	if err := WriteBulkResults(out, rows); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,172 @@
+package bhr_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+// copyFixtures copies the fixtures to a temporary directory, so a test can
+// change them. Remove it when done.
+func copyFixtures(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = filepath.Walk(fixtures, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(fixtures, path)
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(rel)), 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filepath.Join(dir, rel), b, 0644)
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// addToDirectory adds an employee to the directory of a copy of the fixtures.
+func addToDirectory(t *testing.T, dir string, emp map[string]interface{}) {
+	t.Helper()
+	path := filepath.Join(dir, "employees", "directory.json")
+	var directory map[string]interface{}
+	b, err := ioutil.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(b, &directory)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	directory["employees"] = append(directory["employees"].([]interface{}), emp)
+	if b, err = json.Marshal(directory); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadBulkRows(t *testing.T) {
+	tests := []struct {
+		csv, by string
+		wantErr bool
+	}{
+		{"id,department\n2,Sales\n", "id", false},
+		{"EmployeeId,department\n2,Sales\n", "id", false},
+		{"department, Email \nSales,bob@example.com\n", "email", false},
+		{"name,workEmail\nBob Jones,bob@example.com\n", "email", false},
+		{"displayName,department\nBob Jones,Sales\n", "name", false},
+		{"department,jobTitle\nSales,CTO\n", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		_, by, err := bhr.ReadBulkRows(strings.NewReader(tt.csv))
+		if by != tt.by || (err != nil) != tt.wantErr {
+			t.Errorf("ReadBulkRows(%q) = %q, %v, want %q", tt.csv, by, err, tt.by)
+		}
+	}
+
+	rows, _, err := bhr.ReadBulkRows(strings.NewReader("department,id,jobTitle\nSales, 2 ,\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Line != 2 || rows[0].Key != "2" ||
+		len(rows[0].Values) != 1 || rows[0].Values["department"] != "Sales" {
+		t.Errorf("rows = %+v, want line 2, key 2, department=Sales", rows[0])
+	}
+}
+
+// bulkResults reads the status and error of each row of a results CSV.
+func bulkResults(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(records[0], ","); got != "line,key,employeeId,status,changes,error" {
+		t.Errorf("results header %s", got)
+	}
+	var results []string
+	for _, r := range records[1:] {
+		results = append(results, strings.Join(r, "|"))
+	}
+	return results
+}
+
+func TestBulkUpdate(t *testing.T) {
+	dir := copyFixtures(t)
+	defer os.RemoveAll(dir)
+	addToDirectory(t, dir, map[string]interface{}{"id": "5", "displayName": "Bob Jones"})
+
+	input := filepath.Join(dir, "changes.csv")
+	err := ioutil.WriteFile(input, []byte("name,department\n"+
+		"Bob Jones,Sales\n"+
+		"Nobody,Sales\n"+
+		"Carol White,Sales\n"+
+		"Alice Smith,Executive\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := filepath.Join(dir, "results.csv")
+
+	s := bhrtest.NewServer(dir)
+	defer s.Close()
+	c := bhr.BulkUpdateCmd{Client: s.Client(), File: input, Results: results, DryRun: true, Concurrency: 2}
+	if out := captureStdout(t, c.Run); !strings.Contains(out, "4 row(s), 1 to update") {
+		t.Errorf("dry run: %s", out)
+	}
+	for _, r := range s.Requests() {
+		if r.Method == "POST" {
+			t.Errorf("dry run sent %+v", r)
+		}
+	}
+	want := []string{
+		`2|Bob Jones|0|error||2 employees with name "Bob Jones"`,
+		`3|Nobody|0|error||no employee with name "Nobody"`,
+		`4|Carol White|3|dry-run|department=Sales|`,
+		`5|Alice Smith|1|unchanged||`,
+	}
+	if got := bulkResults(t, results); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("dry run results\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	c.DryRun, c.Yes = false, true
+	captureStdout(t, c.Run)
+	var posts []bhrtest.Request
+	for _, r := range s.Requests() {
+		if r.Method == "POST" {
+			posts = append(posts, r)
+		}
+	}
+	if len(posts) != 1 || posts[0].Path != "employees/3" || string(posts[0].Body) != `{"department":"Sales"}` {
+		t.Errorf("POSTs = %+v, want department=Sales for employees/3", posts)
+	}
+	if got := bulkResults(t, results)[2]; got != "4|Carol White|3|updated|department=Sales|" {
+		t.Errorf("applied row = %s, want updated", got)
+	}
+}
@@ -0,0 +1,169 @@
+package bhr
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	Birthday    = "birthday"
+	Anniversary = "anniversary"
+)
+
+// Celebration is an employee's birthday or work anniversary.
+type Celebration struct {
+	Date     time.Time
+	Kind     string
+	Years    int
+	Employee *IndividualEmployee
+}
+
+type CelebrationsCmd struct {
+	Client *Client
+	Days   int
+	Team   string
+}
+
+// Subtree returns the employee whose display name matches name along with
+// everyone reporting to them, directly or indirectly.
+func (d *Directory) Subtree(name string) ([]*Employee, error) {
+	nameRegexp, err := regexp.Compile(name)
+	if err != nil {
+		return nil, err
+	}
+	for i, emp := range d.Employees {
+		if nameRegexp.MatchString(emp.DisplayName) {
+			return subtree(&d.Employees[i]), nil
+		}
+	}
+	return nil, errors.Errorf("no employee matching %q", name)
+}
+
+func subtree(emp *Employee) []*Employee {
+	employees := []*Employee{emp}
+	for _, child := range emp.children {
+		employees = append(employees, subtree(child)...)
+	}
+	return employees
+}
+
+// nextDate returns the first date on or after from falling on month and day.
+func nextDate(from time.Time, month time.Month, day int) time.Time {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)
+	date := time.Date(from.Year(), month, day, 0, 0, 0, 0, time.Local)
+	if date.Before(from) {
+		date = time.Date(from.Year()+1, month, day, 0, 0, 0, 0, time.Local)
+	}
+	return date
+}
+
+// Celebrations returns the birthdays and work anniversaries of active
+// employees in the given number of days from from, in date order. Birthdays
+// are only included where BambooHR lets the API key see them.
+func Celebrations(employees []*IndividualEmployee, from time.Time, days int) []Celebration {
+	until := time.Date(from.Year(), from.Month(), from.Day()+days, 0, 0, 0, 0, time.Local)
+	var celebrations []Celebration
+	for _, e := range employees {
+		if e.Record != nil && strings.EqualFold(e.Record.String("status"), "Inactive") {
+			continue
+		}
+		var month, day int
+		if _, err := fmt.Sscanf(e.Birthday, "%d-%d", &month, &day); err == nil {
+			date := nextDate(from, time.Month(month), day)
+			if date.Before(until) {
+				celebrations = append(celebrations, Celebration{Date: date, Kind: Birthday, Employee: e})
+			}
+		}
+		hired, err := time.ParseInLocation(dateFormat, e.HireDate, time.Local)
+		if err != nil {
+			continue
+		}
+		date := nextDate(from, hired.Month(), hired.Day())
+		if years := date.Year() - hired.Year(); years > 0 && date.Before(until) {
+			celebrations = append(celebrations, Celebration{Date: date, Kind: Anniversary, Years: years, Employee: e})
+		}
+	}
+	sort.SliceStable(celebrations, func(i, j int) bool {
+		if !celebrations[i].Date.Equal(celebrations[j].Date) {
+			return celebrations[i].Date.Before(celebrations[j].Date)
+		}
+		return celebrations[i].Employee.DisplayName < celebrations[j].Employee.DisplayName
+	})
+	return celebrations
+}
+
+// RenderCelebrations writes celebrations as a message for a team channel.
+func RenderCelebrations(celebrations []Celebration, s *strings.Builder) {
+	for _, kind := range []string{Birthday, Anniversary} {
+		var lines []string
+		for _, c := range celebrations {
+			if c.Kind != kind {
+				continue
+			}
+			line := fmt.Sprintf("%s  %s", c.Date.Format("Mon 2 Jan"), c.Employee.DisplayName)
+			if kind == Anniversary {
+				plural := "s"
+				if c.Years == 1 {
+					plural = ""
+				}
+				line += fmt.Sprintf(" (%d year%s)", c.Years, plural)
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		if s.Len() > 0 {
+			s.WriteRune('\n')
+		}
+		if kind == Birthday {
+			s.WriteString("🎂 Birthdays\n")
+		} else {
+			s.WriteString("🎉 Work anniversaries\n")
+		}
+		for _, line := range lines {
+			s.WriteString(line + "\n")
+		}
+	}
+}
+
+func (c *CelebrationsCmd) Run() error {
+	// Former employees aren't celebrated, so the directory is enough.
+	employees, _, err := c.Client.GetAllEmployees(GetAllOptions{Concurrency: 4})
+	if err != nil {
+		return err
+	}
+	if c.Team != "" {
+		dir := c.Client.GetDirectory(func(Employee) bool { return true })
+		team, err := dir.Subtree(c.Team)
+		if err != nil {
+			return err
+		}
+		inTeam := make(map[string]bool)
+		for _, emp := range team {
+			inTeam[emp.ID] = true
+		}
+		var filtered []*IndividualEmployee
+		for _, e := range employees {
+			if inTeam[e.ID] {
+				filtered = append(filtered, e)
+			}
+		}
+		employees = filtered
+	}
+
+	celebrations := Celebrations(employees, time.Now(), c.Days)
+	if len(celebrations) == 0 {
+		fmt.Printf("Nothing to celebrate in the next %d days\n", c.Days)
+		return nil
+	}
+	var result strings.Builder
+	RenderCelebrations(celebrations, &result)
+	fmt.Print(result.String())
+	return nil
+}
@@ -0,0 +1,53 @@
+package bhr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+func TestCelebrations(t *testing.T) {
+	alice := employee("1", "Alice", "", map[string]interface{}{"status": "Active"})
+	alice.HireDate, alice.Birthday = "2015-10-20", "01-02"
+	bob := employee("2", "Bob", "Alice", map[string]interface{}{"status": "Active"})
+	bob.HireDate, bob.Birthday = "2025-10-25", "10-19"
+	carol := employee("3", "Carol", "Alice", map[string]interface{}{"status": "Active"})
+	carol.HireDate = "2026-10-21"
+	dan := employee("4", "Dan", "Bob", map[string]interface{}{"status": "Inactive"})
+	dan.HireDate, dan.Birthday = "2020-10-22", "10-22"
+
+	from := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+	var result strings.Builder
+	bhr.RenderCelebrations(bhr.Celebrations([]*bhr.IndividualEmployee{alice, bob, carol, dan}, from, 14), &result)
+	want := "🎂 Birthdays\n" +
+		"Mon 19 Oct  Bob\n" +
+		"\n" +
+		"🎉 Work anniversaries\n" +
+		"Tue 20 Oct  Alice (11 years)\n" +
+		"Sun 25 Oct  Bob (1 year)\n"
+	if result.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", result.String(), want)
+	}
+}
+
+func TestSubtree(t *testing.T) {
+	dir := mustDirectory(t, `{"employees": [
+		{"id": "1", "displayName": "Alice"},
+		{"id": "2", "displayName": "Bob", "supervisor": "Alice"},
+		{"id": "3", "displayName": "Carol", "supervisor": "Bob"},
+		{"id": "4", "displayName": "Dan", "supervisor": "Alice"}
+	]}`)
+	team, err := dir.Subtree("Bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, emp := range team {
+		names = append(names, emp.DisplayName)
+	}
+	if got := strings.Join(names, ","); got != "Bob,Carol" {
+		t.Errorf("team = %s, want Bob,Carol", got)
+	}
+}
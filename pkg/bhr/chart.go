@@ -0,0 +1,169 @@
+package bhr
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const defaultChartWidth = 80
+
+// sparks are the block characters for sparklines, lowest first.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// barBlocks are the partial block characters for the ends of bars, in eighths.
+var barBlocks = []rune(" ▏▎▍▌▋▊▉█")
+
+// Chart draws charts with Unicode blocks.
+type Chart struct {
+	Width int
+	Color bool
+}
+
+// NewChart returns a chart the width of the terminal, coloured unless
+// NO_COLOR is set or stdout is not a terminal.
+func NewChart() Chart {
+	fd := int(os.Stdout.Fd())
+	_, noColor := os.LookupEnv("NO_COLOR")
+	return Chart{Width: terminalWidth(fd), Color: !noColor && terminal.IsTerminal(fd)}
+}
+
+// terminalWidth returns the width of the terminal, or $COLUMNS if it is not
+// a terminal.
+func terminalWidth(fd int) int {
+	if width, _, err := terminal.GetSize(fd); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return defaultChartWidth
+}
+
+// Bars writes a horizontal bar chart of counts under a title.
+func (c Chart) Bars(title string, counts []Count, s *strings.Builder) {
+	if len(counts) == 0 {
+		return
+	}
+	labelWidth, max := 0, 0
+	for _, count := range counts {
+		if n := utf8.RuneCountInString(count.Name); n > labelWidth {
+			labelWidth = n
+		}
+		if count.Count > max {
+			max = count.Count
+		}
+	}
+	numberWidth := len(strconv.Itoa(max))
+	if labelWidth > c.Width/3 {
+		labelWidth = c.Width / 3
+	}
+	if labelWidth < 1 {
+		labelWidth = 1
+	}
+	barWidth := c.Width - labelWidth - numberWidth - 3
+	if barWidth < 0 {
+		barWidth = 0
+	}
+
+	s.WriteString(title + "\n")
+	for _, count := range counts {
+		label := count.Name
+		if utf8.RuneCountInString(label) > labelWidth {
+			label = string([]rune(label)[:labelWidth-1]) + "…"
+		}
+		s.WriteString(label + strings.Repeat(" ", labelWidth-utf8.RuneCountInString(label)) + " ")
+		if c.Color {
+			s.WriteString("\x1b[36m")
+		}
+		s.WriteString(bar(count.Count, max, barWidth))
+		if c.Color {
+			s.WriteString("\x1b[0m")
+		}
+		s.WriteString(fmt.Sprintf(" %*d\n", numberWidth, count.Count))
+	}
+}
+
+// bar returns a bar of up to width cells for n out of max, using partial
+// blocks for eighths of a cell.
+func bar(n, max, width int) string {
+	if max == 0 || width <= 0 {
+		return strings.Repeat(" ", width)
+	}
+	eighths := n * width * 8 / max
+	if n > 0 && eighths == 0 {
+		eighths = 1
+	}
+	b := strings.Repeat(string(barBlocks[8]), eighths/8)
+	cells := eighths / 8
+	if eighths%8 > 0 {
+		b += string(barBlocks[eighths%8])
+		cells++
+	}
+	return b + strings.Repeat(" ", width-cells)
+}
+
+// Sparkline returns values as a line of block characters scaled to the
+// largest value.
+func Sparkline(values []int) string {
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	var s strings.Builder
+	for _, v := range values {
+		if max == 0 || v <= 0 {
+			s.WriteRune(' ')
+			continue
+		}
+		s.WriteRune(sparks[(v*len(sparks)-1)/max])
+	}
+	return s.String()
+}
+
+// Trend writes a sparkline of the counts with the labels of the first and last.
+func (c Chart) Trend(title string, counts []Count, s *strings.Builder) {
+	if len(counts) == 0 {
+		return
+	}
+	values := make([]int, len(counts))
+	total := 0
+	for i, count := range counts {
+		values[i] = count.Count
+		total += count.Count
+	}
+	if max := c.Width - 2*len(counts[0].Name) - 4; len(values) > max && max > 0 {
+		values = values[len(values)-max:]
+		counts = counts[len(counts)-max:]
+	}
+	s.WriteString(fmt.Sprintf("%s (%d)\n", title, total))
+	spark := Sparkline(values)
+	if c.Color {
+		spark = "\x1b[32m" + spark + "\x1b[0m"
+	}
+	s.WriteString(fmt.Sprintf("%s %s %s\n", counts[0].Name, spark, counts[len(counts)-1].Name))
+}
+
+// monthlyCounts returns the number of dates in each month from the month of
+// since to the month of until.
+func monthlyCounts(dates []time.Time, since, until time.Time) []Count {
+	byMonth := make(map[string]int)
+	for _, date := range dates {
+		byMonth[date.Format("2006-01")]++
+	}
+	var counts []Count
+	month := time.Date(since.Year(), since.Month(), 1, 0, 0, 0, 0, time.Local)
+	for !month.After(until) {
+		name := month.Format("2006-01")
+		counts = append(counts, Count{Name: name, Count: byMonth[name]})
+		month = month.AddDate(0, 1, 0)
+	}
+	return counts
+}
@@ -0,0 +1,37 @@
+package bhr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+func TestSparkline(t *testing.T) {
+	if got, want := bhr.Sparkline([]int{0, 1, 2, 4, 8}), " ▁▂▄█"; got != want {
+		t.Errorf("Sparkline = %q, want %q", got, want)
+	}
+}
+
+func TestChartBars(t *testing.T) {
+	chart := bhr.Chart{Width: 20}
+	var result strings.Builder
+	chart.Bars("Department", []bhr.Count{{Name: "Engineering", Count: 8}, {Name: "Exec", Count: 3}}, &result)
+	want := "Department\n" +
+		"Engin… ██████████ 8\n" +
+		"Exec   ███▊       3\n"
+	if result.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", result.String(), want)
+	}
+}
+
+func TestChartBarsNarrow(t *testing.T) {
+	counts := []bhr.Count{{Name: "Engineering", Count: 8}, {Name: "Exec", Count: 3}}
+	for width := 0; width < 6; width++ {
+		var result strings.Builder
+		bhr.Chart{Width: width}.Bars("Department", counts, &result)
+		if want := "Department\n…  8\n…  3\n"; result.String() != want {
+			t.Errorf("width %d: got %q, want %q", width, result.String(), want)
+		}
+	}
+}
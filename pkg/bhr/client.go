@@ -1,30 +1,235 @@
 package bhr
 
 import (
-	"log"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultBaseURL   = "https://api.bamboohr.com/api/gateway.php/safetyculture/v1"
+	DefaultUserAgent = "bhr/0.0.1"
+	DefaultTimeout   = time.Second * 10
+
+	dateFormat = "2006-01-02"
 )
 
 type Client struct {
 	httpClient *http.Client
 	apiKey     string
+	baseURL    string
+	userAgent  string
+	logger     logrus.FieldLogger
+
+	limiter          *RateLimiter
+	snapshot         *Snapshot
+	holidayLocations HolidayLocations
+
+	mu            sync.Mutex
+	fields        []MetaField
+	lists         []ListField
+	fieldsWarning sync.Once
 }
 
-func NewClient(apiKey string) *Client {
-	return &Client{
-		httpClient: &http.Client{Timeout: time.Second * 10},
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient makes the client send requests with h.
+func WithHTTPClient(h *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = h
+	}
+}
+
+// WithTransport makes the client send requests through t, e.g. a proxy,
+// tracing or test transport. A client given with WithHTTPClient is copied
+// rather than changed.
+func WithTransport(t http.RoundTripper) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Transport = t
+		c.httpClient = &hc
+	}
+}
+
+// WithTimeout sets the time limit for each request. A client given with
+// WithHTTPClient is copied rather than changed.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Timeout = d
+		c.httpClient = &hc
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request, in place
+// of DefaultUserAgent.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithBaseURL sets the URL of the API, including the company domain and
+// version, e.g. https://api.bamboohr.com/api/gateway.php/example/v1.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithLogger sets where requests are logged, at debug level.
+func WithLogger(logger logrus.FieldLogger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithRateLimit limits the client as SetRateLimit does.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(c *Client) {
+		c.SetRateLimit(rate, burst)
+	}
+}
+
+func NewClient(apiKey string, opts ...Option) *Client {
+	c := &Client{
+		httpClient: &http.Client{Timeout: DefaultTimeout},
 		apiKey:     apiKey,
+		baseURL:    DefaultBaseURL,
+		userAgent:  DefaultUserAgent,
+		logger:     logrus.StandardLogger(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 func (c *Client) Request(url string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", url, nil)
+	return c.Do("GET", url, "", nil)
+}
+
+// SetRateLimit limits the client, across all goroutines using it, to rate
+// requests per second with bursts of up to burst requests. A rate of zero
+// removes the limit. It must be called before the client is used.
+func (c *Client) SetRateLimit(rate float64, burst int) {
+	if rate <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = NewRateLimiter(rate, burst)
+}
+
+// RateLimitStats returns how much the rate limit has delayed requests.
+func (c *Client) RateLimitStats() RateLimitStats {
+	if c.limiter == nil {
+		return RateLimitStats{}
+	}
+	return c.limiter.Stats()
+}
+
+// Do makes an authenticated request with a body of the given content type,
+// waiting first if the client is rate limited.
+func (c *Client) Do(method, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, body)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return c.send(req)
+}
+
+// send authenticates and sends req, waiting first if the client is rate
+// limited.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	method, url := req.Method, req.URL.String()
+	if c.snapshot != nil {
+		return nil, errors.Wrapf(ErrOffline, "%s %s", method, url)
+	}
+	if c.limiter != nil {
+		c.limiter.Wait()
 	}
 	req.SetBasicAuth(c.apiKey, "")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-agent", "bhr/0.0.1")
-	return c.httpClient.Do(req)
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	req.Header.Set("User-agent", c.userAgent)
+	start := time.Now()
+	res, err := c.httpClient.Do(req)
+	log := c.logger.WithFields(logrus.Fields{"method": method, "url": url, "duration": time.Since(start)})
+	if err != nil {
+		log.WithError(err).Debug("request failed")
+		return nil, err
+	}
+	log.WithField("status", res.StatusCode).Debug("request")
+	return res, nil
+}
+
+// getJSON requests url and decodes the JSON response body into v.
+func (c *Client) getJSON(url string, v interface{}) error {
+	res, err := c.Request(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("%s: %s", url, res.Status)
+	}
+	return errors.Wrap(json.Unmarshal(body, v), url)
+}
+
+// responseError returns an error describing the response unless its status
+// is 2xx, using BambooHR's error message header or else the body.
+func responseError(method, url string, res *http.Response, body []byte) error {
+	if res.StatusCode >= 200 && res.StatusCode <= 299 {
+		return nil
+	}
+	msg := res.Header.Get("X-BambooHR-Error-Message")
+	if msg == "" {
+		msg = strings.TrimSpace(string(body))
+	}
+	return errors.Errorf("%s %s: %s %s", method, url, res.Status, msg)
+}
+
+// sendJSON makes a request with in encoded as the JSON body and decodes the
+// response body into out, if not nil. It returns the response headers, and an
+// error unless the response status is 2xx.
+func (c *Client) sendJSON(method, url string, in, out interface{}) (http.Header, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Do(method, url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := responseError(method, url, res, body); err != nil {
+		return res.Header, err
+	}
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return res.Header, errors.Wrap(err, url)
+		}
+	}
+	return res.Header, nil
 }
@@ -0,0 +1,25 @@
+package bhr_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestClientOptionsCopyHTTPClient(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	transport, timeout := http.DefaultClient.Transport, http.DefaultClient.Timeout
+	c := s.Client(bhr.WithHTTPClient(http.DefaultClient), bhr.WithTimeout(time.Second),
+		bhr.WithTransport(http.DefaultTransport))
+	if http.DefaultClient.Timeout != timeout || http.DefaultClient.Transport != transport {
+		t.Errorf("options changed http.DefaultClient to %+v", http.DefaultClient)
+	}
+	if e := c.GetEmployee(1); e.DisplayName != "Alice Smith" {
+		t.Errorf("GetEmployee(1) = %+v", e)
+	}
+}
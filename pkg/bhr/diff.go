@@ -0,0 +1,229 @@
+package bhr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// snapshotFileFormat names the snapshots in a store by when they were taken.
+const snapshotFileFormat = "20060102T150405Z.json"
+
+// DirectoryDiff is the difference between two directories.
+type DirectoryDiff struct {
+	Joiners []Employee       `json:"joiners"`
+	Leavers []Employee       `json:"leavers"`
+	Changes []EmployeeChange `json:"changes"`
+}
+
+// EmployeeChange is a change to the title, department or manager of an
+// employee in both directories.
+type EmployeeChange struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+type DiffCmd struct {
+	Client *Client
+	Old    string
+	New    string
+	Since  string
+	Store  string
+	Format string
+}
+
+// DiffDirectories compares two directories, matching employees by ID.
+func DiffDirectories(old, cur *Directory) *DirectoryDiff {
+	diff := &DirectoryDiff{}
+	oldByID := make(map[string]Employee)
+	for _, emp := range old.Employees {
+		oldByID[emp.ID] = emp
+	}
+	curByID := make(map[string]bool)
+	for _, emp := range cur.Employees {
+		curByID[emp.ID] = true
+		prev, ok := oldByID[emp.ID]
+		if !ok {
+			diff.Joiners = append(diff.Joiners, emp)
+			continue
+		}
+		changes := []struct{ field, old, cur string }{
+			{"title", prev.JobTitle, emp.JobTitle},
+			{"department", prev.Department, emp.Department},
+			{"manager", prev.Supervisor, emp.Supervisor},
+		}
+		for _, change := range changes {
+			if change.old != change.cur {
+				diff.Changes = append(diff.Changes, EmployeeChange{
+					ID: emp.ID, Name: emp.DisplayName, Field: change.field, Old: change.old, New: change.cur,
+				})
+			}
+		}
+	}
+	for _, emp := range old.Employees {
+		if !curByID[emp.ID] {
+			diff.Leavers = append(diff.Leavers, emp)
+		}
+	}
+	return diff
+}
+
+// ParseSince parses a time relative to now such as 7d, 2w or 36h, or an
+// absolute date or RFC 3339 timestamp.
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	if strings.HasSuffix(s, "d") || strings.HasSuffix(s, "w") {
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil {
+			if strings.HasSuffix(s, "w") {
+				n *= 7
+			}
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation(dateFormat, s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, errors.Wrapf(err, "invalid time %q, expected e.g. 7d, 2w, 36h or YYYY-MM-DD", s)
+}
+
+// DefaultSnapshotStore returns the directory snapshots are stored in by
+// default.
+func DefaultSnapshotStore() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bhr", "snapshots"), nil
+}
+
+// SaveToStore saves the snapshot in a store directory, named by when it was
+// taken, and returns its path.
+func (s *Snapshot) SaveToStore(store string) (string, error) {
+	if err := os.MkdirAll(store, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(store, s.Taken.UTC().Format(snapshotFileFormat))
+	return path, s.Save(path)
+}
+
+// FindSnapshot returns the path of the latest snapshot in a store taken at or
+// before t.
+func FindSnapshot(store string, t time.Time) (string, error) {
+	files, err := ioutil.ReadDir(store)
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for _, f := range files {
+		taken, err := time.Parse(snapshotFileFormat, f.Name())
+		if err == nil && !taken.After(t) {
+			names = append(names, f.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "", errors.Errorf("no snapshot in %s taken before %s", store, t.Format(time.RFC3339))
+	}
+	sort.Strings(names)
+	return filepath.Join(store, names[len(names)-1]), nil
+}
+
+func loadDirectory(path string) (*Directory, error) {
+	s, err := LoadSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	return s.Dir(func(Employee) bool { return true })
+}
+
+// RenderDiff writes the joiners, leavers and changes in a diff.
+func RenderDiff(diff *DirectoryDiff, s *strings.Builder) {
+	renderEmployees := func(title string, employees []Employee) {
+		if len(employees) == 0 {
+			return
+		}
+		s.WriteString(fmt.Sprintf("%s (%d):\n", title, len(employees)))
+		for _, emp := range employees {
+			s.WriteString(fmt.Sprintf("  %s (%s, %s)\n", emp.DisplayName, emp.JobTitle, emp.Department))
+		}
+		s.WriteRune('\n')
+	}
+	renderEmployees("Joiners", diff.Joiners)
+	renderEmployees("Leavers", diff.Leavers)
+
+	sections := []struct{ field, title string }{
+		{"title", "Title changes"},
+		{"department", "Department moves"},
+		{"manager", "Manager changes"},
+	}
+	for _, section := range sections {
+		var changes []EmployeeChange
+		for _, change := range diff.Changes {
+			if change.Field == section.field {
+				changes = append(changes, change)
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		s.WriteString(fmt.Sprintf("%s (%d):\n", section.title, len(changes)))
+		for _, change := range changes {
+			s.WriteString(fmt.Sprintf("  %s: %s -> %s\n", change.Name, orNone(change.Old), orNone(change.New)))
+		}
+		s.WriteRune('\n')
+	}
+	if len(diff.Joiners)+len(diff.Leavers)+len(diff.Changes) == 0 {
+		s.WriteString("No changes\n")
+	}
+}
+
+func (c *DiffCmd) Run() error {
+	var old, cur *Directory
+	var err error
+	if c.Since != "" {
+		since, err := ParseSince(c.Since, time.Now())
+		if err != nil {
+			return err
+		}
+		if c.Old, err = FindSnapshot(c.Store, since); err != nil {
+			return err
+		}
+	}
+	if old, err = loadDirectory(c.Old); err != nil {
+		return err
+	}
+	if c.New != "" {
+		if cur, err = loadDirectory(c.New); err != nil {
+			return err
+		}
+	} else {
+		cur = c.Client.GetDirectory(func(Employee) bool { return true })
+	}
+
+	diff := DiffDirectories(old, cur)
+	if c.Format == "json" {
+		b, err := json.MarshalIndent(diff, "", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	var result strings.Builder
+	RenderDiff(diff, &result)
+	fmt.Print(result.String())
+	return nil
+}
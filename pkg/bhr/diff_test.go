@@ -0,0 +1,71 @@
+package bhr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+func mustDirectory(t *testing.T, body string) *bhr.Directory {
+	t.Helper()
+	dir, err := bhr.NewDirectory([]byte(body), func(bhr.Employee) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestDiffDirectories(t *testing.T) {
+	old := mustDirectory(t, `{"employees": [
+		{"id": "1", "displayName": "Alice", "jobTitle": "CEO", "department": "Exec"},
+		{"id": "2", "displayName": "Bob", "jobTitle": "Engineer", "department": "Eng", "supervisor": "Alice"},
+		{"id": "3", "displayName": "Carol", "jobTitle": "Engineer", "department": "Eng", "supervisor": "Bob"}
+	]}`)
+	new := mustDirectory(t, `{"employees": [
+		{"id": "1", "displayName": "Alice", "jobTitle": "CEO", "department": "Exec"},
+		{"id": "2", "displayName": "Bob", "jobTitle": "CTO", "department": "Eng", "supervisor": "Alice"},
+		{"id": "4", "displayName": "Dan", "jobTitle": "Engineer", "department": "Eng", "supervisor": "Bob"}
+	]}`)
+
+	diff := bhr.DiffDirectories(old, new)
+	if len(diff.Joiners) != 1 || diff.Joiners[0].ID != "4" {
+		t.Errorf("Joiners = %+v, want Dan", diff.Joiners)
+	}
+	if len(diff.Leavers) != 1 || diff.Leavers[0].ID != "3" {
+		t.Errorf("Leavers = %+v, want Carol", diff.Leavers)
+	}
+	want := bhr.EmployeeChange{ID: "2", Name: "Bob", Field: "title", Old: "Engineer", New: "CTO"}
+	if len(diff.Changes) != 1 || diff.Changes[0] != want {
+		t.Errorf("Changes = %+v, want %+v", diff.Changes, want)
+	}
+
+	var s strings.Builder
+	bhr.RenderDiff(diff, &s)
+	if !strings.Contains(s.String(), "Title changes (1):\n  Bob: Engineer -> CTO\n") {
+		t.Errorf("RenderDiff wrote\n%s", s.String())
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"7d", time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)},
+		{"2w", time.Date(2026, 10, 5, 12, 0, 0, 0, time.UTC)},
+		{"36h", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"2026-10-01T00:00:00Z", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := bhr.ParseSince(tt.in, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseSince(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := bhr.ParseSince("last tuesday", now); err == nil {
+		t.Error("ParseSince(last tuesday) succeeded")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"regexp"
 	"strings"
 )
@@ -56,21 +55,35 @@ type Filters struct {
 type FilterFunc func(e Employee) bool
 
 func (c *Client) GetDirectory(f FilterFunc) (dir *Directory) {
-	dir = &Directory{}
-	dir.employeeByName = make(map[string]*Employee)
-
-	res, err := c.Request("https://api.bamboohr.com/api/gateway.php/safetyculture/v1/employees/directory")
-	if err != nil {
-		log.Fatal(err)
+	var body []byte
+	if c.snapshot != nil {
+		body = c.snapshot.Directory
+	} else {
+		res, err := c.Request(c.baseURL + "/employees/directory")
+		if err != nil {
+			c.logger.Fatal(err)
+		}
+		defer res.Body.Close()
+		body, err = ioutil.ReadAll(res.Body)
+		if err != nil {
+			c.logger.Fatal(err)
+		}
 	}
-	defer res.Body.Close()
-	body, err := ioutil.ReadAll(res.Body)
+	dir, err := NewDirectory(body, f)
 	if err != nil {
-		log.Fatal(err)
+		c.logger.Fatal(string(body), err)
 	}
-	err = json.Unmarshal(body, &dir)
+	return
+}
+
+// NewDirectory decodes a directory as returned by BambooHR and links each
+// employee accepted by f to their supervisor.
+func NewDirectory(body []byte, f FilterFunc) (*Directory, error) {
+	dir := &Directory{}
+	dir.employeeByName = make(map[string]*Employee)
+	err := json.Unmarshal(body, &dir)
 	if err != nil {
-		log.Fatal(string(body), err)
+		return nil, err
 	}
 	for i, emp := range dir.Employees {
 		if !f(emp) {
@@ -88,7 +101,7 @@ func (c *Client) GetDirectory(f FilterFunc) (dir *Directory) {
 			dir.Employees[i].parent = supervisor
 		}
 	}
-	return
+	return dir, nil
 }
 
 func (c *Client) FindEmployeeByName(name string) *Employee {
@@ -0,0 +1,89 @@
+package bhr_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+const fixtures = "../bhrtest/testdata"
+
+// captureStdout returns what fn writes to stdout.
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	err = fn()
+	os.Stdout = stdout
+	w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestGetDirectory(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	dir := s.Client().GetDirectory(func(bhr.Employee) bool { return true })
+	if len(dir.Employees) != 4 {
+		t.Fatalf("got %d employees, want 4", len(dir.Employees))
+	}
+	if len(dir.Fields) == 0 {
+		t.Error("got no fields")
+	}
+	want := []string{"London", "Melbourne", "Sydney"}
+	if got := dir.Locations(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Locations() = %v, want %v", got, want)
+	}
+}
+
+func TestFindEmployeeByName(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+	c := s.Client()
+
+	e := c.FindEmployeeByName("(?i)carol.*white")
+	if e == nil || e.ID != "3" {
+		t.Errorf("FindEmployeeByName(carol white) = %+v, want ID 3", e)
+	}
+	if e := c.FindEmployeeByName("nobody"); e != nil {
+		t.Errorf("FindEmployeeByName(nobody) = %+v, want nil", e)
+	}
+}
+
+func TestDirectoryCmdRun(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	c := bhr.DirectoryCmd{Client: s.Client(), Filters: bhr.Filters{Department: "(?i)", Title: "(?i)"}}
+	got := captureStdout(t, c.Run)
+	want := `
+[ Executive ]
+Alice Smith (Chief Executive Officer)
+
+  [ Engineering ]
+  Bob Jones (Chief Technology Officer)
+    Carol White (Software Engineer)
+
+  [ Sales ]
+  Dan Brown (Account Executive)
+
+`
+	if got != want {
+		t.Errorf("Run() wrote\n%s\nwant\n%s", got, want)
+	}
+}
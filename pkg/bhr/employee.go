@@ -1,23 +1,20 @@
 package bhr
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
-	"image"
-	_ "image/jpeg"
-	_ "image/png"
 	"io/ioutil"
-	"log"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/mattn/go-sixel"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
-func generateFieldsList() string {
+func generateFieldsList() []string {
 	// https://www.bamboohr.com/api/documentation/employees.php
 	// Generated with cat list | paste - - - | awk '{ $1="\"" $1 "\", //"; $2 = $2 ":"; print }'
 	fields := []string{
@@ -121,7 +118,7 @@ func generateFieldsList() string {
 		"noticePeriod",            // list: The employee's notice period
 		"team",                    // list: The employee's team
 	}
-	return strings.Join(fields[:], ",")
+	return fields
 }
 
 type IndividualEmployee struct {
@@ -184,11 +181,15 @@ type IndividualEmployee struct {
 	PhotoURL                string      `json:"photoUrl"`
 	Nationality             interface{} `json:"nationality"`
 	EmployeeStatusDate      string      `json:"employeeStatusDate"`
+	Record                  *Record     `json:"-"`
 }
 
 type EmployeeCmd struct {
-	Client *Client
-	Image  bool
+	Client        *Client
+	Image         bool
+	ImageProtocol ImageProtocol
+	ImageWidth    int
+	Fields        []string
 	EmployeeFilters
 }
 
@@ -197,23 +198,84 @@ type EmployeeFilters struct {
 	ID   int
 }
 
-func (c *Client) GetEmployee(id int) *IndividualEmployee {
-	dir := &IndividualEmployee{}
-	res, err := c.Request(fmt.Sprintf("https://api.bamboohr.com/api/gateway.php/safetyculture/v1/employees/%d?fields=%s",
-		id, generateFieldsList()))
+// EmployeeID resolves the filters to an employee ID, preferring ID over Name.
+// With neither set it returns 0, which BambooHR treats as the API key owner.
+func (f EmployeeFilters) EmployeeID(c *Client) (int, error) {
+	if f.ID != -1 {
+		return f.ID, nil
+	}
+	if f.Name == "" {
+		return 0, nil
+	}
+	e := c.FindEmployeeByName(f.Name)
+	if e == nil {
+		return 0, errors.Errorf("no employee matching %q", f.Name)
+	}
+	return strconv.Atoi(e.ID)
+}
+
+// employeeFieldKeys returns the keys of every field to request for an
+// employee: the documented fields plus any others, such as custom fields,
+// listed in the field metadata. If the metadata can't be read, such as with
+// an API key without access to it, it returns just the documented fields.
+func (c *Client) employeeFieldKeys() ([]string, []MetaField) {
+	keys := generateFieldsList()
+	meta, err := c.Fields()
 	if err != nil {
-		log.Fatal(err)
+		c.fieldsWarning.Do(func() {
+			c.logger.WithError(err).Warn("can't read field metadata, so custom fields are left out")
+		})
+		return keys, nil
 	}
-	defer res.Body.Close()
-	body, err := ioutil.ReadAll(res.Body)
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		seen[key] = true
+	}
+	for _, f := range meta {
+		if !seen[f.Key()] {
+			seen[f.Key()] = true
+			keys = append(keys, f.Key())
+		}
+	}
+	return keys, meta
+}
+
+// GetEmployee returns an employee with every field BambooHR has for them.
+// Fields not declared in IndividualEmployee are available from its Record.
+func (c *Client) GetEmployee(id int) *IndividualEmployee {
+	e, err := c.Employee(id)
 	if err != nil {
-		log.Fatal(err)
+		c.logger.Fatal(err)
 	}
-	err = json.Unmarshal(body, &dir)
+	return e
+}
+
+// Employee is GetEmployee, returning an error instead of exiting.
+func (c *Client) Employee(id int) (*IndividualEmployee, error) {
+	keys, meta := c.employeeFieldKeys()
+	values := make(map[string]interface{})
+	if c.snapshot != nil {
+		all, err := c.snapshot.employee(id)
+		if err != nil {
+			return nil, err
+		}
+		values = all
+	} else {
+		err := c.getJSON(fmt.Sprintf(c.baseURL+"/employees/%d?fields=%s", id, strings.Join(keys, ",")), &values)
+		if err != nil {
+			return nil, err
+		}
+	}
+	body, err := json.Marshal(values)
 	if err != nil {
-		log.Fatal(string(body), err)
+		return nil, err
+	}
+	e := &IndividualEmployee{}
+	if err := json.Unmarshal(body, e); err != nil {
+		return nil, errors.Wrapf(err, "employee %d", id)
 	}
-	return dir
+	e.Record = NewRecord(meta, values)
+	return e, nil
 }
 
 func prettyPrint(i interface{}) string {
@@ -279,48 +341,123 @@ func IRender(e *IndividualEmployee, s *strings.Builder) {
 }
 
 func (c *EmployeeCmd) Run() error {
-	var employee *IndividualEmployee
-	if c.ID != -1 {
-		employee = c.Client.GetEmployee(c.ID)
-	} else if c.Name != "" {
-		e := c.Client.FindEmployeeByName(c.Name)
-		id, err := strconv.Atoi(e.ID)
-		if err != nil {
-			return err
-		}
-		employee = c.Client.GetEmployee(id)
-	} else {
-		employee = c.Client.GetEmployee(0)
-
+	id, err := c.EmployeeID(c.Client)
+	if err != nil {
+		return err
+	}
+	if len(c.Fields) > 0 {
+		return c.runFields(id)
 	}
+	employee := c.Client.GetEmployee(id)
 	var result strings.Builder
 
 	IRender(employee, &result)
 	if c.Image && employee.PhotoUploaded && employee.PhotoURL != "" {
-		err := c.Client.ImageShow(employee.PhotoURL, &result)
+		protocol := c.ImageProtocol
+		if protocol == ImageAuto || protocol == "" {
+			protocol = DetectImageProtocol()
+		}
+		var img strings.Builder
+		rows, err := c.Client.ImageShow(employee.PhotoURL, protocol, c.ImageWidth, &img)
 		if err != nil {
 			return err
 		}
+		cols := c.ImageWidth
+		if cols <= 0 {
+			cols = DefaultImageWidth
+		}
+		details := result.String()
+		result.Reset()
+		result.WriteString(SideBySide(img.String(), protocol, cols, rows, details, terminal.IsTerminal(int(os.Stdout.Fd()))))
 	}
 	fmt.Println()
 	fmt.Println(result.String())
 	return nil
 }
 
-func (c *Client) ImageShow(url string, s *strings.Builder) error {
-	res, err := c.Request(strings.Replace(url, "-1.jpg", "-2.jpg", -1))
+// runFields shows only the requested fields, which may include custom fields.
+func (c *EmployeeCmd) runFields(id int) error {
+	meta, err := c.Client.Fields()
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	defer res.Body.Close()
-	img, _, err := image.Decode(res.Body)
+	fields, err := LookupFields(meta, c.Fields)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.Key())
+	}
+	record, err := c.Client.GetEmployeeFields(id, keys)
 	if err != nil {
 		return err
 	}
-	buf := bufio.NewWriter(os.Stdout)
-	defer buf.Flush()
+	var result strings.Builder
+	FieldsRender(fields, record, &result)
+	fmt.Println()
+	fmt.Println(result.String())
+	return nil
+}
+
+// largePhotoCols is the width in cells above which ImageShow uses the larger
+// variant of a photo.
+const largePhotoCols = 12
+
+// getPhoto returns the photo at url, its content type and its version. If
+// have has an ETag or Last-Modified date and the photo is still that version,
+// it returns no data.
+func (c *Client) getPhoto(url string, have PhotoVersion) ([]byte, string, PhotoVersion, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, "", have, err
+	}
+	req.Header.Set("Accept", "image/*")
+	if have.ETag != "" {
+		req.Header.Set("If-None-Match", have.ETag)
+	}
+	if have.LastModified != "" {
+		req.Header.Set("If-Modified-Since", have.LastModified)
+	}
+	res, err := c.send(req)
+	if err != nil {
+		return nil, "", have, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotModified {
+		return nil, "", have, nil
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", have, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, "", have, errors.Errorf("%s: %s", url, res.Status)
+	}
+	version := PhotoVersion{ETag: res.Header.Get("ETag"), LastModified: res.Header.Get("Last-Modified")}
+	return body, res.Header.Get("Content-Type"), version, nil
+}
 
-	enc := sixel.NewEncoder(buf)
-	enc.Dither = true
-	return enc.Encode(img)
+// ImageShow writes the photo at url to s using protocol, scaled to cols
+// cells wide, and returns how many rows it takes. Photo URLs ending -1.jpg
+// have a larger -2.jpg variant, which is used for all but small images.
+func (c *Client) ImageShow(url string, protocol ImageProtocol, cols int, s *strings.Builder) (int, error) {
+	if cols <= 0 {
+		cols = DefaultImageWidth
+	}
+	var body []byte
+	var err error
+	if cols > largePhotoCols && strings.HasSuffix(url, "-1.jpg") {
+		body, _, _, err = c.getPhoto(strings.TrimSuffix(url, "-1.jpg")+"-2.jpg", PhotoVersion{})
+	}
+	if body == nil {
+		if body, _, _, err = c.getPhoto(url, PhotoVersion{}); err != nil {
+			return 0, err
+		}
+	}
+	rows, err := ImageRows(body, cols)
+	if err != nil {
+		return 0, errors.Wrap(err, url)
+	}
+	return rows, WriteImage(s, body, protocol, cols)
 }
@@ -0,0 +1,117 @@
+package bhr_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestGetEmployee(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	e := s.Client().GetEmployee(1)
+	if e.DisplayName != "Alice Smith" || e.HireDate != "2015-02-01" {
+		t.Errorf("GetEmployee(1) = %+v", e)
+	}
+	tests := []struct{ key, want string }{
+		{"4001", "M"},
+		{"payRate", "250000.00 AUD"},
+		{"includeInPayroll", "Yes"},
+		{"terminationDate", ""},
+		{"hireDate", "2015-02-01"},
+	}
+	for _, tt := range tests {
+		if got := e.Record.Format(tt.key); got != tt.want {
+			t.Errorf("Record.Format(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+	if e.Record.Name("4001") != "Shirt size" {
+		t.Errorf("Record.Name(4001) = %q, want Shirt size", e.Record.Name("4001"))
+	}
+}
+
+func TestEmployeeID(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+	c := s.Client()
+
+	tests := []struct {
+		filters bhr.EmployeeFilters
+		want    int
+		wantErr bool
+	}{
+		{bhr.EmployeeFilters{ID: 4}, 4, false},
+		{bhr.EmployeeFilters{ID: -1}, 0, false},
+		{bhr.EmployeeFilters{ID: -1, Name: "(?i)bob"}, 2, false},
+		{bhr.EmployeeFilters{ID: -1, Name: "nobody"}, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := tt.filters.EmployeeID(c)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("%+v.EmployeeID() = %d, %v, want %d", tt.filters, got, err, tt.want)
+		}
+	}
+}
+
+func TestGetAllEmployees(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+	c := s.Client()
+
+	// The fixtures have no custom report, so employees come from the directory.
+	employees, complete, err := c.GetAllEmployees(bhr.GetAllOptions{Concurrency: 2})
+	if err != nil || complete || len(employees) != 4 {
+		t.Fatalf("GetAllEmployees() = %d employees, %v, %v, want 4 incomplete", len(employees), complete, err)
+	}
+	if employees[0].DisplayName != "Alice Smith" {
+		t.Errorf("first employee = %q, want Alice Smith", employees[0].DisplayName)
+	}
+
+	snapshot, err := c.TakeSnapshot(true, bhr.GetAllOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline := bhr.NewClient("", bhr.WithSnapshot(snapshot))
+	employees, complete, err = offline.GetAllEmployees(bhr.GetAllOptions{})
+	if err != nil || !complete || len(employees) != 4 {
+		t.Errorf("offline GetAllEmployees() = %d employees, %v, %v, want 4 complete", len(employees), complete, err)
+	}
+
+	if _, err := c.Employee(99); err == nil {
+		t.Error("Employee(99) succeeded")
+	}
+}
+
+func TestGetEmployeeWithoutFieldMetadata(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// Only the employee, as if the API key can't read meta/fields.
+	b, err := ioutil.ReadFile(filepath.Join(fixtures, "employees", "1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "employees"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "employees", "1.json"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := bhrtest.NewServer(dir)
+	defer s.Close()
+
+	e, err := s.Client().Employee(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.DisplayName != "Alice Smith" || e.Record.Format("hireDate") != "2015-02-01" {
+		t.Errorf("Employee(1) = %+v", e)
+	}
+}
@@ -0,0 +1,195 @@
+package bhr
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultBaseDN is the DN LDIF entries are exported below by default.
+const DefaultBaseDN = "ou=people,dc=example,dc=com"
+
+// ExportCmd exports the employees in the directory as vCards or LDIF entries,
+// optionally with their photos.
+type ExportCmd struct {
+	Client      *Client
+	Format      string
+	Output      string
+	Photos      bool
+	BaseDN      string
+	Concurrency int
+	Filters
+}
+
+// vcardEscaper escapes vCard text values, writing any line break as \n.
+var vcardEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\r", `\n`, "\n", `\n`)
+
+// fold splits a content line into lines of at most width bytes, each after
+// the first starting with a space, without splitting UTF-8 characters.
+func fold(line string, width int, newline string) string {
+	var s strings.Builder
+	max := width
+	for len(line) > max {
+		n := max
+		for n > 0 && !utf8.RuneStart(line[n]) {
+			n--
+		}
+		s.WriteString(line[:n] + newline + " ")
+		line = line[n:]
+		max = width - 1 // for the leading space
+	}
+	s.WriteString(line + newline)
+	return s.String()
+}
+
+// WriteVCard writes emp as a vCard 3.0, with their photo if it is an image.
+func WriteVCard(emp Employee, photo []byte, s *strings.Builder) {
+	line := func(name, value string) {
+		s.WriteString(fold(name+":"+value, 75, "\r\n"))
+	}
+	line("BEGIN", "VCARD")
+	line("VERSION", "3.0")
+	line("N", vcardEscaper.Replace(emp.LastName)+";"+vcardEscaper.Replace(emp.FirstName)+";;;")
+	line("FN", vcardEscaper.Replace(emp.DisplayName))
+	if emp.JobTitle != "" {
+		line("TITLE", vcardEscaper.Replace(emp.JobTitle))
+	}
+	if emp.Department != "" {
+		line("ORG", ";"+vcardEscaper.Replace(emp.Department))
+	}
+	if emp.WorkPhone != "" {
+		line("TEL;TYPE=WORK,VOICE", vcardEscaper.Replace(emp.WorkPhone))
+	}
+	if emp.WorkEmail != "" {
+		line("EMAIL;TYPE=INTERNET,WORK", vcardEscaper.Replace(emp.WorkEmail))
+	}
+	if contentType := http.DetectContentType(photo); photo != nil && strings.HasPrefix(contentType, "image/") {
+		photoType := strings.ToUpper(strings.TrimPrefix(contentType, "image/"))
+		line("PHOTO;ENCODING=b;TYPE="+photoType, base64.StdEncoding.EncodeToString(photo))
+	}
+	line("UID", "bamboohr-"+emp.ID)
+	line("END", "VCARD")
+}
+
+// ldifSafe reports whether an LDIF value can be written as is rather than in
+// base64, as RFC 2849 defines SAFE-STRING.
+func ldifSafe(value string) bool {
+	if value == "" {
+		return true
+	}
+	if value[0] == ' ' || value[0] == ':' || value[0] == '<' || value[len(value)-1] == ' ' {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c == 0 || c == '\n' || c == '\r' || c > 127 {
+			return false
+		}
+	}
+	return true
+}
+
+// ldapEscaper escapes attribute values in distinguished names.
+var ldapEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, "+", `\+`, `"`, `\"`, "<", `\<`, ">", `\>`, ";", `\;`, "=", `\=`)
+
+// WriteLDIF writes emp as an inetOrgPerson entry below baseDN, with their
+// photo if not nil.
+func WriteLDIF(emp Employee, photo []byte, baseDN string, s *strings.Builder) {
+	attr := func(name, value string) {
+		if value == "" {
+			return
+		}
+		if ldifSafe(value) {
+			s.WriteString(fold(name+": "+value, 76, "\n"))
+		} else {
+			s.WriteString(fold(name+":: "+base64.StdEncoding.EncodeToString([]byte(value)), 76, "\n"))
+		}
+	}
+	attr("dn", "uid="+ldapEscaper.Replace(emp.ID)+","+baseDN)
+	for _, class := range []string{"top", "person", "organizationalPerson", "inetOrgPerson"} {
+		attr("objectClass", class)
+	}
+	attr("uid", emp.ID)
+	attr("cn", emp.DisplayName)
+	sn := emp.LastName
+	if sn == "" {
+		// sn is required.
+		sn = emp.DisplayName
+	}
+	attr("sn", sn)
+	attr("givenName", emp.FirstName)
+	attr("displayName", emp.DisplayName)
+	attr("title", emp.JobTitle)
+	attr("ou", emp.Department)
+	attr("telephoneNumber", emp.WorkPhone)
+	attr("mail", emp.WorkEmail)
+	if photo != nil && http.DetectContentType(photo) == "image/jpeg" {
+		s.WriteString(fold("jpegPhoto:: "+base64.StdEncoding.EncodeToString(photo), 76, "\n"))
+	}
+	s.WriteRune('\n')
+}
+
+func (c *ExportCmd) Run() error {
+	var write func(emp Employee, photo []byte, s *strings.Builder)
+	switch c.Format {
+	case "vcard":
+		write = WriteVCard
+	case "ldif":
+		write = func(emp Employee, photo []byte, s *strings.Builder) {
+			WriteLDIF(emp, photo, c.BaseDN, s)
+		}
+	default:
+		return errors.Errorf("unknown export format %q", c.Format)
+	}
+
+	f := Filter(c.Filters)
+	dir := c.Client.GetDirectory(f)
+	var employees []Employee
+	for _, emp := range dir.Employees {
+		if f(emp) {
+			employees = append(employees, emp)
+		}
+	}
+	photos := make([][]byte, len(employees))
+	if c.Photos {
+		var mu sync.Mutex
+		var failures []string
+		parallel(len(employees), c.Concurrency, func(i int) {
+			emp := employees[i]
+			if !emp.PhotoUploaded || emp.PhotoURL == "" {
+				return
+			}
+			photo, _, _, err := c.Client.getPhoto(emp.PhotoURL, PhotoVersion{})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", emp.DisplayName, err))
+				return
+			}
+			photos[i] = photo
+		})
+		for _, failure := range failures {
+			fmt.Fprintln(os.Stderr, failure)
+		}
+	}
+
+	var result strings.Builder
+	for i, emp := range employees {
+		write(emp, photos[i], &result)
+	}
+	if c.Output == "" || c.Output == "-" {
+		fmt.Print(result.String())
+		return nil
+	}
+	if err := ioutil.WriteFile(c.Output, []byte(result.String()), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d employees to %s\n", len(employees), c.Output)
+	return nil
+}
@@ -0,0 +1,89 @@
+package bhr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+var exported = bhr.Employee{
+	ID:          "7",
+	DisplayName: "Zoë Smith, Jr",
+	FirstName:   "Zoë",
+	LastName:    "Smith",
+	JobTitle:    "R&D; Lead",
+	Department:  "Engineering",
+	WorkPhone:   "+61 2 5550 0000",
+	WorkEmail:   "zoe@example.com",
+}
+
+func TestWriteVCard(t *testing.T) {
+	var result strings.Builder
+	bhr.WriteVCard(exported, nil, &result)
+	want := "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"N:Smith;Zoë;;;\r\n" +
+		"FN:Zoë Smith\\, Jr\r\n" +
+		"TITLE:R&D\\; Lead\r\n" +
+		"ORG:;Engineering\r\n" +
+		"TEL;TYPE=WORK,VOICE:+61 2 5550 0000\r\n" +
+		"EMAIL;TYPE=INTERNET,WORK:zoe@example.com\r\n" +
+		"UID:bamboohr-7\r\n" +
+		"END:VCARD\r\n"
+	if result.String() != want {
+		t.Errorf("got\n%q\nwant\n%q", result.String(), want)
+	}
+}
+
+func TestWriteVCardLineBreaksAndPhotos(t *testing.T) {
+	emp := exported
+	emp.JobTitle = "Lead\r\nEngineer\rand\nManager"
+	var result strings.Builder
+	bhr.WriteVCard(emp, []byte("not an image"), &result)
+	if !strings.Contains(result.String(), "TITLE:Lead\\nEngineer\\nand\\nManager\r\n") {
+		t.Errorf("line breaks not escaped:\n%q", result.String())
+	}
+	if strings.Contains(result.String(), "PHOTO") {
+		t.Errorf("wrote a photo that isn't an image:\n%q", result.String())
+	}
+}
+
+func TestWriteLDIF(t *testing.T) {
+	var result strings.Builder
+	bhr.WriteLDIF(exported, nil, "ou=people,dc=example,dc=com", &result)
+	for _, line := range []string{
+		"dn: uid=7,ou=people,dc=example,dc=com\n",
+		"objectClass: inetOrgPerson\n",
+		"cn:: Wm/DqyBTbWl0aCwgSnI=\n",
+		"sn: Smith\n",
+		"title: R&D; Lead\n",
+		"ou: Engineering\n",
+		"mail: zoe@example.com\n",
+	} {
+		if !strings.Contains(result.String(), line) {
+			t.Errorf("missing %q in\n%s", line, result.String())
+		}
+	}
+}
+
+func TestExportPhotos(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	c := bhr.ExportCmd{Client: s.Client(), Format: "vcard", Photos: true, Concurrency: 2}
+	out := captureStdout(t, c.Run)
+	if n := strings.Count(out, "BEGIN:VCARD"); n != 4 {
+		t.Errorf("exported %d vCards, want 4", n)
+	}
+	if n := strings.Count(out, "PHOTO;ENCODING=b;TYPE=JPEG:"); n != 1 {
+		t.Errorf("embedded %d photos, want 1 for Alice", n)
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line not folded: %q", line)
+			break
+		}
+	}
+}
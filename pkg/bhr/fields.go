@@ -0,0 +1,200 @@
+package bhr
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+)
+
+// FieldID is the ID of a field in the field metadata, which BambooHR returns
+// as either a number or a string.
+type FieldID string
+
+func (id *FieldID) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*id = FieldID(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*id = FieldID(n)
+	return nil
+}
+
+// MetaField describes a standard or custom employee field.
+type MetaField struct {
+	ID    FieldID `json:"id"`
+	Name  string  `json:"name"`
+	Type  string  `json:"type"`
+	Alias string  `json:"alias"`
+}
+
+// Key returns the name used to request the field, its alias if it has one and
+// its ID otherwise. Custom fields usually have no alias.
+func (f MetaField) Key() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return string(f.ID)
+}
+
+// ListField describes a list field and the options it may take.
+type ListField struct {
+	FieldID  FieldID      `json:"fieldId"`
+	Alias    string       `json:"alias"`
+	Name     string       `json:"name"`
+	Multiple string       `json:"multiple"`
+	Options  []ListOption `json:"options"`
+}
+
+type ListOption struct {
+	ID       FieldID `json:"id"`
+	Name     string  `json:"name"`
+	Archived string  `json:"archived"`
+}
+
+// Key returns the name used to request the field, as for MetaField.Key.
+func (l ListField) Key() string {
+	if l.Alias != "" {
+		return l.Alias
+	}
+	return string(l.FieldID)
+}
+
+// Option returns the option matching name case insensitively, ignoring
+// archived options.
+func (l ListField) Option(name string) (ListOption, bool) {
+	for _, o := range l.Options {
+		if o.Archived != "yes" && strings.EqualFold(o.Name, name) {
+			return o, true
+		}
+	}
+	return ListOption{}, false
+}
+
+type FieldsCmd struct {
+	Client *Client
+	Filter string
+}
+
+// Fields returns the metadata of every employee field. It is fetched once per
+// client.
+func (c *Client) Fields() ([]MetaField, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.snapshot != nil {
+		return c.snapshot.Fields, nil
+	}
+	if c.fields != nil {
+		return c.fields, nil
+	}
+	var fields []MetaField
+	if err := c.getJSON(c.baseURL+"/meta/fields", &fields); err != nil {
+		return nil, err
+	}
+	c.fields = fields
+	return fields, nil
+}
+
+// Lists returns the metadata of every list field. It is fetched once per
+// client.
+func (c *Client) Lists() ([]ListField, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lists != nil {
+		return c.lists, nil
+	}
+	var lists []ListField
+	if err := c.getJSON(c.baseURL+"/meta/lists", &lists); err != nil {
+		return nil, err
+	}
+	c.lists = lists
+	return lists, nil
+}
+
+// LookupFields resolves each name to a field, matching its alias, its ID or
+// (case insensitively) its display name.
+func LookupFields(fields []MetaField, names []string) ([]MetaField, error) {
+	var result []MetaField
+	for _, name := range names {
+		var found *MetaField
+		for i, f := range fields {
+			if f.Alias == name || string(f.ID) == name {
+				found = &fields[i]
+				break
+			}
+			if found == nil && strings.EqualFold(f.Name, name) {
+				found = &fields[i]
+			}
+		}
+		if found == nil {
+			return nil, errors.Errorf("unknown field %q", name)
+		}
+		result = append(result, *found)
+	}
+	return result, nil
+}
+
+// GetEmployeeFields returns a record of the requested fields of an employee
+// keyed by the names they were requested with.
+func (c *Client) GetEmployeeFields(id int, keys []string) (*Record, error) {
+	values := make(map[string]interface{})
+	if c.snapshot != nil {
+		all, err := c.snapshot.employee(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			values[key] = all[key]
+		}
+	} else {
+		err := c.getJSON(fmt.Sprintf(c.baseURL+"/employees/%d?fields=%s", id, strings.Join(keys, ",")), &values)
+		if err != nil {
+			return nil, err
+		}
+	}
+	meta, err := c.Fields()
+	if err != nil {
+		return nil, err
+	}
+	return NewRecord(meta, values), nil
+}
+
+// FieldsRender writes the values of the fields, labelled by their display
+// names, in the order given.
+func FieldsRender(fields []MetaField, record *Record, s *strings.Builder) {
+	pad := 0
+	for _, f := range fields {
+		if len(f.Name)+2 > pad {
+			pad = len(f.Name) + 2
+		}
+	}
+	for _, f := range fields {
+		s.WriteString(fmt.Sprintf("%-*s%s\n", pad, f.Name+": ", record.Format(f.Key())))
+	}
+}
+
+func (c *FieldsCmd) Run() error {
+	fields, err := c.Client.Fields()
+	if err != nil {
+		return err
+	}
+	filterRegexp := regexp.MustCompile(c.Filter)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Field\tType\tName")
+	for _, f := range fields {
+		if !filterRegexp.MatchString(f.Key()) && !filterRegexp.MatchString(f.Name) {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.Key(), f.Type, f.Name)
+	}
+	return w.Flush()
+}
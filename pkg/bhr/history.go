@@ -0,0 +1,88 @@
+package bhr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type HistoryCmd struct {
+	Client *Client
+	EmployeeFilters
+}
+
+// GetJobInfo returns an employee's job information rows sorted by effective
+// date, oldest first.
+func (c *Client) GetJobInfo(employeeID int) ([]JobInfo, error) {
+	rows, err := c.GetTable(employeeID, "jobInfo")
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]JobInfo, 0, len(rows))
+	for _, row := range rows {
+		jobs = append(jobs, *row.(*JobInfo))
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Date < jobs[j].Date
+	})
+	return jobs, nil
+}
+
+// RenderHistory writes a timeline of the changes between consecutive job
+// information rows.
+func RenderHistory(jobs []JobInfo, s *strings.Builder) {
+	pad := 12
+	for i, job := range jobs {
+		if i == 0 {
+			s.WriteString(fmt.Sprintf("%-*s%s", pad, job.Date, job.JobTitle))
+			if job.Department != "" {
+				s.WriteString(fmt.Sprintf(", %s", job.Department))
+			}
+			if job.Location != "" {
+				s.WriteString(fmt.Sprintf(" (%s)", job.Location))
+			}
+			if job.ReportsTo != "" {
+				s.WriteString(fmt.Sprintf(", reporting to %s", job.ReportsTo))
+			}
+			s.WriteRune('\n')
+			continue
+		}
+		prev := jobs[i-1]
+		changes := []struct{ name, from, to string }{
+			{"Title", prev.JobTitle, job.JobTitle},
+			{"Department", prev.Department, job.Department},
+			{"Location", prev.Location, job.Location},
+			{"Manager", prev.ReportsTo, job.ReportsTo},
+		}
+		date := job.Date
+		for _, change := range changes {
+			if change.from == change.to {
+				continue
+			}
+			s.WriteString(fmt.Sprintf("%-*s%s: %s -> %s\n", pad, date, change.name, orNone(change.from), orNone(change.to)))
+			date = ""
+		}
+	}
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func (c *HistoryCmd) Run() error {
+	id, err := c.EmployeeID(c.Client)
+	if err != nil {
+		return err
+	}
+	jobs, err := c.Client.GetJobInfo(id)
+	if err != nil {
+		return err
+	}
+	var result strings.Builder
+	RenderHistory(jobs, &result)
+	fmt.Println(result.String())
+	return nil
+}
@@ -0,0 +1,49 @@
+package bhr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestGetJobInfo(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	// The fixture lists the newest row first.
+	jobs, err := s.Client().GetJobInfo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 || jobs[0].Date != "2016-07-11" || jobs[1].Date != "2019-01-01" {
+		t.Fatalf("GetJobInfo(2) = %+v, want 2016-07-11 then 2019-01-01", jobs)
+	}
+
+	var result strings.Builder
+	bhr.RenderHistory(jobs, &result)
+	want := "2016-07-11  Senior Software Engineer, Engineering (Melbourne), reporting to Alice Smith\n" +
+		"2019-01-01  Title: Senior Software Engineer -> Chief Technology Officer\n" +
+		"            Location: Melbourne -> Sydney\n"
+	if result.String() != want {
+		t.Errorf("RenderHistory wrote\n%s\nwant\n%s", result.String(), want)
+	}
+}
+
+func TestRenderHistoryEmptyFields(t *testing.T) {
+	jobs := []bhr.JobInfo{
+		{Date: "2020-01-01", JobTitle: "Engineer"},
+		{Date: "2021-01-01", JobTitle: "Engineer", Department: "Platform", ReportsTo: "Bob"},
+		{Date: "2022-01-01", JobTitle: "Engineer", Department: "Platform"},
+	}
+	var result strings.Builder
+	bhr.RenderHistory(jobs, &result)
+	want := "2020-01-01  Engineer\n" +
+		"2021-01-01  Department: (none) -> Platform\n" +
+		"            Manager: (none) -> Bob\n" +
+		"2022-01-01  Manager: Bob -> (none)\n"
+	if result.String() != want {
+		t.Errorf("RenderHistory wrote\n%s\nwant\n%s", result.String(), want)
+	}
+}
@@ -0,0 +1,198 @@
+package bhr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// WhosOut is an entry from the whos out endpoint, either an employee's
+// time off or a company holiday.
+type WhosOut struct {
+	ID         int    `json:"id"`
+	Type       string `json:"type"`
+	EmployeeID int    `json:"employeeId"`
+	Name       string `json:"name"`
+	Start      string `json:"start"`
+	End        string `json:"end"`
+}
+
+// Holiday is a company holiday along with the locations it applies to.
+type Holiday struct {
+	Name      string
+	Start     time.Time
+	End       time.Time
+	Locations []string
+}
+
+type HolidaysCmd struct {
+	Client   *Client
+	Start    time.Time
+	End      time.Time
+	Location string
+}
+
+// HolidayLocations maps holiday names to the locations they apply to, for
+// holidays whose names don't say.
+type HolidayLocations map[string][]string
+
+// WithHolidayLocations makes the client use m to find the locations of the
+// holidays it names, see HolidayLocations.Locations.
+func WithHolidayLocations(m HolidayLocations) Option {
+	return func(c *Client) {
+		c.holidayLocations = m
+	}
+}
+
+// LoadHolidayLocations reads holiday locations from a JSON object of holiday
+// names to lists of locations.
+func LoadHolidayLocations(path string) (HolidayLocations, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m HolidayLocations
+	return m, errors.Wrap(json.Unmarshal(b, &m), path)
+}
+
+// Locations returns which of locations a holiday applies to. A holiday in the
+// mapping applies to the locations listed for it. Otherwise a holiday whose
+// name mentions locations as whole words applies to those, ignoring a location
+// mentioned only as part of a longer one, such as York in New York. Any other
+// holiday applies to every location.
+func (m HolidayLocations) Locations(holiday string, locations []string) []string {
+	for name, mapped := range m {
+		if strings.EqualFold(name, holiday) {
+			return mapped
+		}
+	}
+
+	type span struct{ location, start, end int }
+	var spans []span
+	for i, location := range locations {
+		re := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(location))
+		for _, match := range re.FindAllStringIndex(holiday, -1) {
+			before, _ := utf8.DecodeLastRuneInString(holiday[:match[0]])
+			after, _ := utf8.DecodeRuneInString(holiday[match[1]:])
+			if isWordRune(before) || isWordRune(after) {
+				// Part of a longer word.
+				continue
+			}
+			spans = append(spans, span{i, match[0], match[1]})
+		}
+	}
+	var matched []string
+	seen := make(map[int]bool)
+	for _, a := range spans {
+		within := false
+		for _, b := range spans {
+			if b.end-b.start > a.end-a.start && b.start <= a.start && a.end <= b.end {
+				within = true
+			}
+		}
+		if !within && !seen[a.location] {
+			seen[a.location] = true
+			matched = append(matched, locations[a.location])
+		}
+	}
+	if len(matched) == 0 {
+		return locations
+	}
+	sort.Strings(matched)
+	return matched
+}
+
+// isWordRune reports whether r is part of a word, in any script.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func (c *Client) WhosOut(start, end time.Time) ([]WhosOut, error) {
+	var entries []WhosOut
+	err := c.getJSON(fmt.Sprintf(c.baseURL+"/time_off/whos_out/?start=%s&end=%s",
+		start.Format(dateFormat), end.Format(dateFormat)), &entries)
+	return entries, err
+}
+
+// Holidays returns the company holidays between start and end. BambooHR does
+// not say which offices a holiday applies to, so they are found from the
+// holiday names or the client's mapping (see WithHolidayLocations).
+func (c *Client) Holidays(start, end time.Time) ([]Holiday, error) {
+	entries, err := c.WhosOut(start, end)
+	if err != nil {
+		return nil, err
+	}
+	locations := c.GetDirectory(func(Employee) bool { return true }).Locations()
+
+	var holidays []Holiday
+	for _, entry := range entries {
+		if entry.Type != "holiday" {
+			continue
+		}
+		h := Holiday{Name: entry.Name}
+		if h.Start, err = time.Parse(dateFormat, entry.Start); err != nil {
+			return nil, err
+		}
+		if h.End, err = time.Parse(dateFormat, entry.End); err != nil {
+			return nil, err
+		}
+		h.Locations = c.holidayLocations.Locations(entry.Name, locations)
+		holidays = append(holidays, h)
+	}
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Start.Before(holidays[j].Start)
+	})
+	return holidays, nil
+}
+
+// Locations returns the sorted, distinct locations of the employees in the
+// directory.
+func (d *Directory) Locations() []string {
+	seen := make(map[string]bool)
+	var locations []string
+	for _, emp := range d.Employees {
+		if emp.Location == "" || seen[emp.Location] {
+			continue
+		}
+		seen[emp.Location] = true
+		locations = append(locations, emp.Location)
+	}
+	sort.Strings(locations)
+	return locations
+}
+
+func (c *HolidaysCmd) Run() error {
+	holidays, err := c.Client.Holidays(c.Start, c.End)
+	if err != nil {
+		return err
+	}
+	locationRegexp := regexp.MustCompile(c.Location)
+
+	var result strings.Builder
+	for _, h := range holidays {
+		var locations []string
+		for _, location := range h.Locations {
+			if locationRegexp.MatchString(location) {
+				locations = append(locations, location)
+			}
+		}
+		if len(locations) == 0 {
+			continue
+		}
+		dates := h.Start.Format(dateFormat)
+		if !h.End.Equal(h.Start) {
+			dates += " - " + h.End.Format(dateFormat)
+		}
+		result.WriteString(fmt.Sprintf("%-25s%s (%s)\n", dates, h.Name, strings.Join(locations, ", ")))
+	}
+	fmt.Println(result.String())
+	return nil
+}
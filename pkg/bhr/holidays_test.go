@@ -0,0 +1,58 @@
+package bhr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestHolidayLocations(t *testing.T) {
+	locations := []string{"London", "Malmö", "New York", "Sydney", "York", "Zürich"}
+	mapping := bhr.HolidayLocations{"Boxing Day": {"London", "Sydney"}}
+	tests := []struct{ holiday, want string }{
+		{"Thanksgiving (New York)", "New York"},
+		{"Yorkshire Day (York)", "York"},
+		{"New York and York founders day", "New York,York"},
+		{"Yorkshire Day", "London,Malmö,New York,Sydney,York,Zürich"},
+		{"Boxing Day", "London,Sydney"},
+		{"Christmas Day", "London,Malmö,New York,Sydney,York,Zürich"},
+		{"Sechseläuten (Zürich)", "Zürich"},
+		{"Malmö festival", "Malmö"},
+		{"London Sydney swap day", "London,Sydney"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(mapping.Locations(tt.holiday, locations), ","); got != tt.want {
+			t.Errorf("Locations(%q) = %s, want %s", tt.holiday, got, tt.want)
+		}
+	}
+}
+
+func TestHolidays(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+	c := s.Client(bhr.WithHolidayLocations(bhr.HolidayLocations{"Christmas Day": {"London", "Sydney"}}))
+
+	start := time.Date(2026, 8, 1, 0, 0, 0, 0, time.Local)
+	holidays, err := c.Holidays(start, start.AddDate(1, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, h := range holidays {
+		got = append(got, h.Start.Format("2006-01-02")+" "+h.Name+" "+strings.Join(h.Locations, ","))
+	}
+	want := []string{
+		"2026-08-31 Summer Bank Holiday (London) London",
+		"2026-11-03 Melbourne Cup Melbourne",
+		"2026-12-25 Christmas Day London,Sydney",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Holidays() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if r := s.Requests()[0]; r.Path != "time_off/whos_out" || r.Query != "start=2026-08-01&end=2027-08-01" {
+		t.Errorf("first request %+v, want whos_out from 2026-08-01 to 2027-08-01", r)
+	}
+}
@@ -0,0 +1,287 @@
+package bhr
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-sixel"
+	"github.com/pkg/errors"
+)
+
+// ImageProtocol is how images are shown in the terminal.
+type ImageProtocol string
+
+const (
+	ImageAuto   ImageProtocol = "auto"
+	ImageSixel  ImageProtocol = "sixel"
+	ImageKitty  ImageProtocol = "kitty"
+	ImageITerm  ImageProtocol = "iterm"
+	ImageBlocks ImageProtocol = "blocks"
+	ImageASCII  ImageProtocol = "ascii"
+)
+
+// ImageProtocols are the protocols ParseImageProtocol accepts.
+var ImageProtocols = []ImageProtocol{ImageAuto, ImageSixel, ImageKitty, ImageITerm, ImageBlocks, ImageASCII}
+
+const (
+	// DefaultImageWidth is the default width of images in cells.
+	DefaultImageWidth = 20
+
+	// cellWidth and cellHeight are the typical size of a terminal cell in
+	// pixels, for protocols that size images in pixels.
+	cellWidth  = 10
+	cellHeight = 20
+)
+
+// asciiRamp are the characters for ASCII images, darkest first.
+const asciiRamp = " .:-=+*#%@"
+
+func ParseImageProtocol(s string) (ImageProtocol, error) {
+	for _, p := range ImageProtocols {
+		if strings.EqualFold(s, string(p)) {
+			return p, nil
+		}
+	}
+	return "", errors.Errorf("unknown image protocol %q", s)
+}
+
+// DetectImageProtocol guesses the best protocol the terminal supports from
+// its environment, falling back to Unicode half blocks, or ASCII if NO_COLOR
+// is set.
+func DetectImageProtocol() ImageProtocol {
+	term := os.Getenv("TERM")
+	termProgram := os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || termProgram == "ghostty":
+		return ImageKitty
+	case termProgram == "iTerm.app" || termProgram == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return ImageITerm
+	case strings.Contains(term, "sixel") || strings.HasPrefix(term, "mlterm") ||
+		strings.HasPrefix(term, "foot") || strings.HasPrefix(term, "yaft"):
+		return ImageSixel
+	}
+	if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+		return ImageASCII
+	}
+	return ImageBlocks
+}
+
+// ImageRows returns how many rows of cells an image, encoded as data, takes
+// when shown cols cells wide.
+func ImageRows(data []byte, cols int) (int, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	return imageRows(config.Width, config.Height, cols), nil
+}
+
+func imageRows(width, height, cols int) int {
+	if width == 0 {
+		return 1
+	}
+	rows := (cols*height*cellWidth + width*cellHeight/2) / (width * cellHeight)
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// WriteImage writes an image, encoded as data, to a terminal using protocol,
+// scaled to cols cells wide.
+func WriteImage(w io.Writer, data []byte, protocol ImageProtocol, cols int) error {
+	if protocol == ImageAuto || protocol == "" {
+		protocol = DetectImageProtocol()
+	}
+	if cols <= 0 {
+		cols = DefaultImageWidth
+	}
+	rows, err := ImageRows(data, cols)
+	if err != nil {
+		return err
+	}
+	if protocol == ImageITerm {
+		_, err := fmt.Fprintf(w, "\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a\n",
+			len(data), cols, rows, base64.StdEncoding.EncodeToString(data))
+		return err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	buf := bufio.NewWriter(w)
+	defer buf.Flush()
+	switch protocol {
+	case ImageSixel:
+		enc := sixel.NewEncoder(buf)
+		enc.Dither = true
+		if err := enc.Encode(resize(img, cols*cellWidth, rows*cellHeight)); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(buf)
+		return err
+	case ImageKitty:
+		return writeKitty(buf, img, cols, rows)
+	case ImageBlocks:
+		writeBlocks(buf, img, cols, rows)
+		return nil
+	case ImageASCII:
+		writeASCII(buf, img, cols, rows)
+		return nil
+	}
+	return errors.Errorf("unknown image protocol %q", protocol)
+}
+
+// resize scales img to width by height pixels, averaging the pixels each
+// new pixel covers.
+func resize(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		if y1 == y0 {
+			y1++
+		}
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+			if x1 == x0 {
+				x1++
+			}
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a, n = r+pr, g+pg, b+pb, a+pa, n+1
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{uint8(r / n >> 8), uint8(g / n >> 8), uint8(b / n >> 8), uint8(a / n >> 8)})
+		}
+	}
+	return dst
+}
+
+// writeKitty writes img as a PNG with the kitty graphics protocol, scaled to
+// cols by rows cells. The protocol limits each escape sequence to 4096 bytes
+// of base64.
+func writeKitty(w io.Writer, img image.Image, cols, rows int) error {
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return err
+	}
+	data := base64.StdEncoding.EncodeToString(b.Bytes())
+	for first := true; ; first = false {
+		chunk := data
+		if len(chunk) > 4096 {
+			chunk = chunk[:4096]
+		}
+		data = data[len(chunk):]
+		more := 0
+		if data != "" {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(w, "\x1b_Ga=T,f=100,c=%d,r=%d,m=%d;%s\x1b\\", cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(w, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+		if data == "" {
+			break
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// sample returns the colour of img at (x, y) of a cols by rows grid, as 8 bit
+// red, green and blue.
+func sample(img image.Image, x, y, cols, rows int) (uint32, uint32, uint32) {
+	bounds := img.Bounds()
+	px := bounds.Min.X + (2*x+1)*bounds.Dx()/(2*cols)
+	py := bounds.Min.Y + (2*y+1)*bounds.Dy()/(2*rows)
+	r, g, b, _ := img.At(px, py).RGBA()
+	return r >> 8, g >> 8, b >> 8
+}
+
+// writeBlocks draws img cols by rows cells with upper half blocks, each cell
+// showing two pixels in its foreground and background colours.
+func writeBlocks(w io.Writer, img image.Image, cols, rows int) {
+	for y := 0; y < 2*rows; y += 2 {
+		for x := 0; x < cols; x++ {
+			tr, tg, tb := sample(img, x, y, cols, 2*rows)
+			br, bg, bb := sample(img, x, y+1, cols, 2*rows)
+			fmt.Fprintf(w, "\x1b[38;2;%d;%d;%dm\x1b[48;2;%d;%d;%dm▀", tr, tg, tb, br, bg, bb)
+		}
+		fmt.Fprint(w, "\x1b[0m\n")
+	}
+}
+
+// writeASCII draws img cols by rows characters in shades of ASCII characters.
+func writeASCII(w io.Writer, img image.Image, cols, rows int) {
+	for y := 0; y < rows; y++ {
+		line := make([]byte, cols)
+		for x := 0; x < cols; x++ {
+			r, g, b := sample(img, x, y, cols, rows)
+			luma := (299*r + 587*g + 114*b) / 1000
+			line[x] = asciiRamp[luma*uint32(len(asciiRamp))/256]
+		}
+		fmt.Fprintf(w, "%s\n", line)
+	}
+}
+
+// Graphics reports whether the protocol draws images as graphics rather than
+// characters.
+func (p ImageProtocol) Graphics() bool {
+	return p == ImageSixel || p == ImageKitty || p == ImageITerm
+}
+
+// SideBySide returns an image, as written by WriteImage cols by rows cells,
+// with text to its right. Images drawn with characters are joined to the
+// text line by line. Graphics need the cursor moved back up beside them,
+// which only works on a terminal, so otherwise the text comes first and the
+// image after it.
+func SideBySide(img string, protocol ImageProtocol, cols, rows int, text string, tty bool) string {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	var s strings.Builder
+	if !protocol.Graphics() {
+		imgLines := strings.Split(strings.TrimSuffix(img, "\n"), "\n")
+		for i := 0; i < len(imgLines) || i < len(lines); i++ {
+			if i < len(imgLines) {
+				s.WriteString(imgLines[i])
+			} else {
+				s.WriteString(strings.Repeat(" ", cols))
+			}
+			if i < len(lines) {
+				s.WriteString("  " + lines[i])
+			}
+			s.WriteRune('\n')
+		}
+		return s.String()
+	}
+	if !tty {
+		return text + img
+	}
+	s.WriteString(img)
+	s.WriteString(fmt.Sprintf("\x1b[%dA", rows))
+	for i, line := range lines {
+		if i == rows {
+			s.WriteString(strings.Join(lines[i:], "\n") + "\n")
+			return s.String()
+		}
+		s.WriteString(fmt.Sprintf("\x1b[%dC  %s\n", cols, line))
+	}
+	if len(lines) < rows {
+		s.WriteString(fmt.Sprintf("\x1b[%dB", rows-len(lines)))
+	}
+	return s.String()
+}
@@ -0,0 +1,103 @@
+package bhr_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+// halves returns a PNG black on the left half and white on the right.
+func halves(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := width / 2; x < width; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestWriteImage(t *testing.T) {
+	data := halves(t, 8, 8)
+	var b bytes.Buffer
+	if err := bhr.WriteImage(&b, data, bhr.ImageASCII, 4); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "  @@\n  @@\n"; got != want {
+		t.Errorf("ascii = %q, want %q", got, want)
+	}
+
+	b.Reset()
+	if err := bhr.WriteImage(&b, data, bhr.ImageBlocks, 4); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(b.String(), "▀"); got != 8 {
+		t.Errorf("blocks drew %d cells, want 8 (4x2)", got)
+	}
+	if !strings.Contains(b.String(), "\x1b[38;2;255;255;255m") {
+		t.Errorf("blocks missing white: %q", b.String())
+	}
+
+	b.Reset()
+	if err := bhr.WriteImage(&b, data, bhr.ImageKitty, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(b.String(), "\x1b_Ga=T,f=100,c=20,r=10,m=0;") {
+		t.Errorf("unexpected kitty output %q", b.String())
+	}
+}
+
+func TestParseImageProtocol(t *testing.T) {
+	if p, err := bhr.ParseImageProtocol("Kitty"); err != nil || p != bhr.ImageKitty {
+		t.Errorf("ParseImageProtocol(Kitty) = %q, %v", p, err)
+	}
+	if _, err := bhr.ParseImageProtocol("png"); err == nil {
+		t.Error("ParseImageProtocol(png) should fail")
+	}
+}
+
+func TestImageShow(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+	c := s.Client()
+
+	for _, tt := range []struct {
+		cols int
+		want string
+	}{{8, "photos/1-1.jpg"}, {16, "photos/1-2.jpg"}} {
+		var result strings.Builder
+		rows, err := c.ImageShow(s.URL+"/photos/1-1.jpg", bhr.ImageASCII, tt.cols, &result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows != tt.cols/2 || strings.Count(result.String(), "\n") != rows {
+			t.Errorf("%d cols: %d rows of\n%s\nwant %d", tt.cols, rows, result.String(), tt.cols/2)
+		}
+		requests := s.Requests()
+		if got := requests[len(requests)-1].Path; got != tt.want {
+			t.Errorf("%d cols: requested %s, want %s", tt.cols, got, tt.want)
+		}
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	got := bhr.SideBySide("##\n##\n", bhr.ImageASCII, 2, 2, "Name: Alice\nEmail: a@example.com\nPhone: 1\n", false)
+	want := "##  Name: Alice\n##  Email: a@example.com\n    Phone: 1\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := bhr.SideBySide("<sixel>\n", bhr.ImageSixel, 2, 1, "Name: Alice\n", false); got != "Name: Alice\n<sixel>\n" {
+		t.Errorf("without a terminal got %q, want the details before the image", got)
+	}
+}
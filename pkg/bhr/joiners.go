@@ -0,0 +1,163 @@
+package bhr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Movement is an employee joining or leaving on a date.
+type Movement struct {
+	Date     time.Time
+	Employee *IndividualEmployee
+}
+
+type MovementsCmd struct {
+	Client    *Client
+	Leavers   bool
+	Since     time.Time
+	Until     time.Time
+	ByManager bool
+	Chart     bool
+	Filters
+}
+
+// Employee returns the directory entry of the employee.
+func (e *IndividualEmployee) Employee() Employee {
+	return Employee{
+		ID:            e.ID,
+		DisplayName:   e.DisplayName,
+		FirstName:     e.FirstName,
+		LastName:      e.LastName,
+		PreferredName: e.PreferredName,
+		JobTitle:      e.JobTitle,
+		WorkPhone:     e.WorkPhone,
+		WorkEmail:     e.WorkEmail,
+		Department:    e.Department,
+		Location:      e.Location,
+		Division:      e.Division,
+		Supervisor:    e.Supervisor,
+		PhotoUploaded: e.PhotoUploaded,
+		PhotoURL:      e.PhotoURL,
+	}
+}
+
+// Movements returns the employees, active or not, who were hired (or, if
+// leavers is true, terminated and are now inactive) between the local days of
+// since and until inclusive, sorted by date.
+func Movements(employees []*IndividualEmployee, leavers bool, since, until time.Time) []Movement {
+	since, until = startOfDay(since), startOfDay(until)
+	key := "hireDate"
+	if leavers {
+		key = "terminationDate"
+	}
+	var movements []Movement
+	for _, e := range employees {
+		if e.Record == nil {
+			continue
+		}
+		date, err := e.Record.Date(key)
+		if err != nil || date.IsZero() || date.Before(since) || date.After(until) {
+			continue
+		}
+		if leavers && strings.EqualFold(e.Record.String("status"), "Active") {
+			// They have since been rehired.
+			continue
+		}
+		movements = append(movements, Movement{Date: date, Employee: e})
+	}
+	sort.SliceStable(movements, func(i, j int) bool {
+		return movements[i].Date.Before(movements[j].Date)
+	})
+	return movements
+}
+
+// startOfDay returns midnight at the start of t's day in the local time zone,
+// which is how record dates are parsed.
+func startOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+func renderMovement(m Movement, s *strings.Builder, level int) {
+	Indent(s, level)
+	s.WriteString(fmt.Sprintf("%s  %s (%s, %s)\n", m.Date.Format(dateFormat),
+		m.Employee.DisplayName, m.Employee.JobTitle, m.Employee.Department))
+}
+
+// RenderMovements writes movements in date order, or grouped under their
+// managers.
+func RenderMovements(movements []Movement, byManager bool, s *strings.Builder) {
+	if !byManager {
+		for _, m := range movements {
+			renderMovement(m, s, 0)
+		}
+		return
+	}
+	var managers []string
+	byName := make(map[string][]Movement)
+	for _, m := range movements {
+		manager := m.Employee.Supervisor
+		if _, ok := byName[manager]; !ok {
+			managers = append(managers, manager)
+		}
+		byName[manager] = append(byName[manager], m)
+	}
+	sort.Strings(managers)
+	for _, manager := range managers {
+		s.WriteString(fmt.Sprintf("[ %s ]\n", orNone(manager)))
+		for _, m := range byName[manager] {
+			renderMovement(m, s, 1)
+		}
+		s.WriteRune('\n')
+	}
+}
+
+// RenderMovementsChart writes a sparkline of movements by month between
+// since and until and a bar chart of them by department.
+func RenderMovementsChart(movements []Movement, since, until time.Time, chart Chart, s *strings.Builder) {
+	dates := make([]time.Time, len(movements))
+	employees := make([]*Employee, len(movements))
+	for i, m := range movements {
+		dates[i] = m.Date
+		emp := m.Employee.Employee()
+		employees[i] = &emp
+	}
+	chart.Trend("By month", monthlyCounts(dates, since, until), s)
+	s.WriteRune('\n')
+	chart.Bars("Department", counts(employees, func(e *Employee) string { return e.Department }), s)
+}
+
+func (c *MovementsCmd) Run() error {
+	employees, complete, err := c.Client.GetAllEmployees(GetAllOptions{Concurrency: 4})
+	if err != nil {
+		return err
+	}
+	if !complete && c.Leavers {
+		return errors.New("can't list leavers: former employees are not available")
+	}
+	filter := Filter(c.Filters)
+	var filtered []*IndividualEmployee
+	for _, e := range employees {
+		if filter(e.Employee()) {
+			filtered = append(filtered, e)
+		}
+	}
+
+	movements := Movements(filtered, c.Leavers, c.Since, c.Until)
+	if len(movements) == 0 {
+		fmt.Println("None")
+		return nil
+	}
+	var result strings.Builder
+	if c.Chart {
+		RenderMovementsChart(movements, c.Since, c.Until, NewChart(), &result)
+	} else {
+		RenderMovements(movements, c.ByManager, &result)
+	}
+	fmt.Print(result.String())
+	return nil
+}
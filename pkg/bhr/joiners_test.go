@@ -0,0 +1,73 @@
+package bhr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+func employee(id, name, supervisor string, values map[string]interface{}) *bhr.IndividualEmployee {
+	return &bhr.IndividualEmployee{
+		ID:          id,
+		DisplayName: name,
+		Supervisor:  supervisor,
+		Record:      bhr.NewRecord(nil, values),
+	}
+}
+
+func TestMovements(t *testing.T) {
+	employees := []*bhr.IndividualEmployee{
+		employee("1", "Alice", "", map[string]interface{}{"hireDate": "2015-02-01", "status": "Active"}),
+		employee("2", "Bob", "Alice", map[string]interface{}{"hireDate": "2026-10-12", "status": "Active"}),
+		employee("3", "Carol", "Alice", map[string]interface{}{"hireDate": "2026-10-01", "status": "Active"}),
+		employee("4", "Dan", "Bob", map[string]interface{}{
+			"hireDate": "2020-01-06", "terminationDate": "2026-10-09", "status": "Inactive"}),
+		employee("5", "Erin", "Bob", map[string]interface{}{
+			"hireDate": "2026-10-13", "terminationDate": "2026-10-02", "status": "Active"}),
+	}
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	until := time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local)
+
+	var names []string
+	for _, m := range bhr.Movements(employees, false, since, until) {
+		names = append(names, m.Employee.DisplayName)
+	}
+	if got := strings.Join(names, ","); got != "Carol,Bob" {
+		t.Errorf("joiners = %s, want Carol,Bob", got)
+	}
+
+	leavers := bhr.Movements(employees, true, since, until)
+	if len(leavers) != 1 || leavers[0].Employee.DisplayName != "Dan" {
+		t.Errorf("leavers = %+v, want Dan", leavers)
+	}
+
+	var s strings.Builder
+	bhr.RenderMovements(bhr.Movements(employees, false, since, until), true, &s)
+	want := "[ Alice ]\n  2026-10-01  Carol (, )\n  2026-10-12  Bob (, )\n\n"
+	if s.String() != want {
+		t.Errorf("RenderMovements wrote %q, want %q", s.String(), want)
+	}
+}
+
+func TestMovementsDayBoundary(t *testing.T) {
+	employees := []*bhr.IndividualEmployee{
+		employee("1", "Alice", "", map[string]interface{}{"hireDate": "2026-09-30"}),
+		employee("2", "Bob", "", map[string]interface{}{"hireDate": "2026-10-01"}),
+		employee("3", "Carol", "", map[string]interface{}{"hireDate": "2026-10-12"}),
+		employee("4", "Dan", "", map[string]interface{}{"hireDate": "2026-10-13"}),
+	}
+	// Relative times such as --since 30d fall part way through a day, and
+	// --until defaults to now, but whole days are included.
+	since := time.Date(2026, 10, 1, 15, 4, 0, 0, time.Local)
+	until := time.Date(2026, 10, 12, 9, 30, 0, 0, time.Local)
+
+	var names []string
+	for _, m := range bhr.Movements(employees, false, since, until) {
+		names = append(names, m.Employee.DisplayName)
+	}
+	if got := strings.Join(names, ","); got != "Bob,Carol" {
+		t.Errorf("joiners = %s, want Bob,Carol", got)
+	}
+}
@@ -0,0 +1,296 @@
+package bhr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// PhotoSizes are the sizes BambooHR serves employee photos in, largest first.
+var PhotoSizes = []string{"original", "large", "medium", "small", "xs", "tiny"}
+
+// photoManifest is the file in a photo directory recording the version of
+// each downloaded photo, so unchanged photos aren't downloaded again.
+const photoManifest = ".bhr-photos.json"
+
+// PhotoVersion identifies the version of a photo for conditional requests.
+type PhotoVersion struct {
+	File         string `json:"file"`
+	Size         string `json:"size"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+type PhotosCmd struct {
+	Client      *Client
+	Output      string
+	Size        string
+	NameBy      string
+	Concurrency int
+	Filters
+}
+
+// ParsePhotoSize checks size is one of PhotoSizes.
+func ParsePhotoSize(size string) (string, error) {
+	for _, s := range PhotoSizes {
+		if strings.EqualFold(size, s) {
+			return s, nil
+		}
+	}
+	return "", errors.Errorf("unknown photo size %q, expected one of %s", size, strings.Join(PhotoSizes, ", "))
+}
+
+// GetPhoto returns an employee's photo at one of PhotoSizes, its content type
+// and its version. If the photo is still the version have, it returns no
+// data.
+func (c *Client) GetPhoto(id, size string, have PhotoVersion) ([]byte, string, PhotoVersion, error) {
+	return c.getPhoto(fmt.Sprintf("%s/employees/%s/photo/%s", c.baseURL, id, size), have)
+}
+
+// photoExt returns the file extension for a photo of the given content type.
+func photoExt(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	}
+	return ".jpg"
+}
+
+func loadPhotoManifest(dir string) (map[string]PhotoVersion, error) {
+	manifest := make(map[string]PhotoVersion)
+	b, err := ioutil.ReadFile(filepath.Join(dir, photoManifest))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return manifest, errors.Wrap(json.Unmarshal(b, &manifest), photoManifest)
+}
+
+func savePhotoManifest(dir string, manifest map[string]PhotoVersion) error {
+	b, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, photoManifest), b, 0644)
+}
+
+// photoNameEscaper keeps photo file names within the output directory.
+var photoNameEscaper = strings.NewReplacer("/", "_", `\`, "_", "..", "_")
+
+// photoName returns the file name, without extension, to save an employee's
+// photo as: their ID, or their email address if nameBy is "email" and they
+// have one.
+func photoName(emp Employee, nameBy string) string {
+	name := emp.ID
+	if nameBy == "email" && emp.WorkEmail != "" {
+		name = emp.WorkEmail
+	}
+	return photoNameEscaper.Replace(name)
+}
+
+func (c *PhotosCmd) Run() error {
+	if err := os.MkdirAll(c.Output, 0755); err != nil {
+		return err
+	}
+	manifest, err := loadPhotoManifest(c.Output)
+	if err != nil {
+		return err
+	}
+	f := Filter(c.Filters)
+	dir := c.Client.GetDirectory(f)
+	var employees []Employee
+	for _, emp := range dir.Employees {
+		if f(emp) && emp.PhotoUploaded {
+			employees = append(employees, emp)
+		}
+	}
+
+	var mu sync.Mutex
+	var downloaded, unchanged int
+	var failures []string
+	parallel(len(employees), c.Concurrency, func(i int) {
+		emp := employees[i]
+		mu.Lock()
+		have := manifest[emp.ID]
+		mu.Unlock()
+		if filepath.Base(have.File) != have.File {
+			// Ignore files the manifest puts outside the output directory.
+			have = PhotoVersion{}
+		}
+		if _, err := os.Stat(filepath.Join(c.Output, have.File)); have.File == "" || have.Size != c.Size || err != nil {
+			// Download it again if it has gone or is the wrong size.
+			have = PhotoVersion{File: have.File}
+		}
+
+		data, contentType, version, err := c.Client.GetPhoto(emp.ID, c.Size, have)
+		if err == nil && data != nil {
+			version.File = photoName(emp, c.NameBy) + photoExt(contentType)
+			version.Size = c.Size
+			err = ioutil.WriteFile(filepath.Join(c.Output, version.File), data, 0644)
+			if err == nil && have.File != "" && have.File != version.File {
+				os.Remove(filepath.Join(c.Output, have.File))
+			}
+		} else if err == nil {
+			// Unchanged, but --name-by may have changed what it's called.
+			version.File = photoName(emp, c.NameBy) + filepath.Ext(have.File)
+			if version.File != have.File {
+				err = os.Rename(filepath.Join(c.Output, have.File), filepath.Join(c.Output, version.File))
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("%s: %v", emp.DisplayName, err))
+		case data == nil:
+			unchanged++
+			manifest[emp.ID] = version
+		default:
+			downloaded++
+			manifest[emp.ID] = version
+		}
+	})
+	if err := savePhotoManifest(c.Output, manifest); err != nil {
+		return err
+	}
+
+	sort.Strings(failures)
+	for _, failure := range failures {
+		fmt.Println(failure)
+	}
+	fmt.Printf("Downloaded %d, unchanged %d, failed %d\n", downloaded, unchanged, len(failures))
+	if len(failures) > 0 {
+		return errors.Errorf("%d photos failed to download", len(failures))
+	}
+	return nil
+}
+
+const (
+	// MinPhotoSize is the smallest width and height BambooHR accepts for a
+	// photo, in pixels.
+	MinPhotoSize = 150
+
+	// maxPhotoSize is the size photos larger than it are scaled down to before
+	// uploading.
+	maxPhotoSize = 1024
+)
+
+type PhotoUploadCmd struct {
+	Client *Client
+	File   string
+	Crop   bool
+	Yes    bool
+	EmployeeFilters
+}
+
+// PreparePhoto checks an image, encoded as data, is big enough to be a photo
+// and returns it as a square JPEG no larger than BambooHR needs. A photo that
+// isn't square is cropped to the square in its centre if crop is true, and
+// otherwise refused.
+func PreparePhoto(data []byte, crop bool) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	side := width
+	if height < side {
+		side = height
+	}
+	if side < MinPhotoSize {
+		return nil, errors.Errorf("photo is %dx%d, but must be at least %dx%d", width, height, MinPhotoSize, MinPhotoSize)
+	}
+	if width != height && !crop {
+		return nil, errors.Errorf("photo is %dx%d, but must be square", width, height)
+	}
+	if width != height {
+		x := bounds.Min.X + (width-side)/2
+		y := bounds.Min.Y + (height-side)/2
+		square := image.NewRGBA(image.Rect(0, 0, side, side))
+		draw.Draw(square, square.Bounds(), img, image.Pt(x, y), draw.Src)
+		img = square
+	}
+	if side > maxPhotoSize {
+		img = resize(img, maxPhotoSize, maxPhotoSize)
+	}
+	var b bytes.Buffer
+	if err := jpeg.Encode(&b, img, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// UploadPhoto replaces an employee's photo with a JPEG, as prepared by
+// PreparePhoto.
+func (c *Client) UploadPhoto(id int, photo []byte) error {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", fmt.Sprintf("%d.jpg", id))
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(photo); err != nil {
+		return err
+	}
+	if err := form.Close(); err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/employees/%d/photo", c.baseURL, id)
+	res, err := c.Do("POST", url, form.FormDataContentType(), &body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	return responseError("POST", url, res, b)
+}
+
+func (c *PhotoUploadCmd) Run() error {
+	data, err := ioutil.ReadFile(c.File)
+	if err != nil {
+		return err
+	}
+	photo, err := PreparePhoto(data, c.Crop)
+	if err != nil {
+		return errors.Wrap(err, c.File)
+	}
+	id, err := c.EmployeeID(c.Client)
+	if err != nil {
+		return err
+	}
+	// Upload to the API key owner's own employee ID.
+	if id, err = c.Client.ownerID(id); err != nil {
+		return err
+	}
+	if !c.Yes && !confirm(fmt.Sprintf("Replace the photo of employee %d with %s?", id, c.File)) {
+		return errors.New("aborted")
+	}
+	if err := c.Client.UploadPhoto(id, photo); err != nil {
+		return err
+	}
+	fmt.Println("Uploaded")
+	return nil
+}
@@ -0,0 +1,129 @@
+package bhr_test
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestPhotosDownload(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+	dir, err := ioutil.TempDir("", "photos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := bhr.PhotosCmd{Client: s.Client(), Output: dir, Size: "large", NameBy: "email", Concurrency: 2}
+	if out := captureStdout(t, c.Run); !strings.Contains(out, "Downloaded 1, unchanged 0, failed 0") {
+		t.Errorf("first download: %s", out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "alice@example.com.jpg")); err != nil {
+		t.Error(err)
+	}
+
+	if out := captureStdout(t, c.Run); !strings.Contains(out, "Downloaded 0, unchanged 1, failed 0") {
+		t.Errorf("second download: %s", out)
+	}
+	requests := s.Requests()
+	last := requests[len(requests)-1]
+	if last.Path != "employees/1/photo/large" {
+		t.Errorf("last request %+v, want employees/1/photo/large", last)
+	}
+
+	// Unchanged photos are renamed to follow --name-by.
+	c.NameBy = "id"
+	if out := captureStdout(t, c.Run); !strings.Contains(out, "Downloaded 0, unchanged 1, failed 0") {
+		t.Errorf("renaming download: %s", out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "1.jpg")); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "alice@example.com.jpg")); !os.IsNotExist(err) {
+		t.Errorf("old photo still there: %v", err)
+	}
+}
+
+func jpegOf(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	if err := jpeg.Encode(&b, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestPreparePhoto(t *testing.T) {
+	tests := []struct {
+		width, height int
+		crop          bool
+		want          int
+		err           string
+	}{
+		{200, 200, false, 200, ""},
+		{300, 200, true, 200, ""},
+		{300, 200, false, 0, "must be square"},
+		{100, 100, true, 0, "at least 150x150"},
+		{2000, 2000, false, 1024, ""},
+	}
+	for _, tt := range tests {
+		photo, err := bhr.PreparePhoto(jpegOf(t, tt.width, tt.height), tt.crop)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%dx%d: error %v, want %q", tt.width, tt.height, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%dx%d: %v", tt.width, tt.height, err)
+			continue
+		}
+		config, err := jpeg.DecodeConfig(bytes.NewReader(photo))
+		if err != nil || config.Width != tt.want || config.Height != tt.want {
+			t.Errorf("%dx%d: prepared %dx%d (%v), want %dx%d", tt.width, tt.height, config.Width, config.Height, err, tt.want, tt.want)
+		}
+	}
+}
+
+func TestUploadPhoto(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+	if err := s.Client().UploadPhoto(2, []byte("jpeg")); err != nil {
+		t.Fatal(err)
+	}
+	requests := s.Requests()
+	if len(requests) != 1 || requests[0].Method != "POST" || requests[0].Path != "employees/2/photo" ||
+		!bytes.Contains(requests[0].Body, []byte(`name="file"; filename="2.jpg"`)) {
+		t.Errorf("Requests() = %+v", requests)
+	}
+}
+
+func TestPhotoUploadOwner(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+	f, err := ioutil.TempFile("", "photo*.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(jpegOf(t, 200, 200)); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	c := bhr.PhotoUploadCmd{Client: s.Client(), File: f.Name(), Yes: true, EmployeeFilters: bhr.EmployeeFilters{ID: -1}}
+	captureStdout(t, c.Run)
+	requests := s.Requests()
+	if last := requests[len(requests)-1]; last.Method != "POST" || last.Path != "employees/1/photo" {
+		t.Errorf("last request %+v, want POST employees/1/photo", last)
+	}
+}
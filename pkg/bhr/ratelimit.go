@@ -0,0 +1,73 @@
+package bhr
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate of requests. It is safe for
+// concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	stats  RateLimitStats
+}
+
+// RateLimitStats records how much a RateLimiter has delayed requests.
+type RateLimitStats struct {
+	Requests int
+	Waits    int
+	Waited   time.Duration
+}
+
+// NewRateLimiter returns a limiter allowing rate requests per second on
+// average and up to burst requests at once.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be made. Each caller reserves a token under
+// the lock and then sleeps until it is due, so waiting callers are served in
+// order.
+func (l *RateLimiter) Wait() {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.stats.Requests++
+	if wait > 0 {
+		l.stats.Waits++
+		l.stats.Waited += wait
+	}
+	l.mu.Unlock()
+
+	time.Sleep(wait)
+}
+
+// Stats returns how many requests the limiter has let through and how long
+// they waited.
+func (l *RateLimiter) Stats() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
@@ -0,0 +1,64 @@
+package bhr_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := bhr.NewRateLimiter(100, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		l.Wait()
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Millisecond {
+		t.Errorf("burst of 3 took %v, want no wait", elapsed)
+	}
+	if stats := l.Stats(); stats.Requests != 3 || stats.Waits != 0 || stats.Waited != 0 {
+		t.Errorf("after burst, Stats() = %+v", stats)
+	}
+
+	// At 100 a second each of the next three waits up to 10ms for a token;
+	// oversleeping shortens the wait for the one after.
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		l.Wait()
+	}
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("3 more requests took %v, want about 30ms", elapsed)
+	}
+	stats := l.Stats()
+	if stats.Requests != 6 || stats.Waits != 3 {
+		t.Errorf("Stats() = %+v, want 6 requests, 3 waits", stats)
+	}
+	if stats.Waited < 15*time.Millisecond || stats.Waited > 31*time.Millisecond {
+		t.Errorf("waited %v, want about 30ms", stats.Waited)
+	}
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	l := bhr.NewRateLimiter(1000, 5)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Wait()
+		}()
+	}
+	wg.Wait()
+
+	// 15 requests beyond the burst take at least 15ms at 1000 a second, less
+	// any tokens refilled while the goroutines started.
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("20 requests took %v, want at least about 15ms", elapsed)
+	}
+	if stats := l.Stats(); stats.Requests != 20 || stats.Waits == 0 || stats.Waits > 15 {
+		t.Errorf("Stats() = %+v, want 20 requests and up to 15 waits", stats)
+	}
+}
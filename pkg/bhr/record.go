@@ -0,0 +1,198 @@
+package bhr
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Record is an employee record holding every field BambooHR returned, typed
+// according to the field metadata. Unlike IndividualEmployee it preserves
+// fields it doesn't know about, including custom fields.
+type Record struct {
+	fields map[string]MetaField
+	values map[string]interface{}
+}
+
+// NewRecord returns a record of values keyed by field key (see MetaField.Key),
+// typed by the given field metadata.
+func NewRecord(fields []MetaField, values map[string]interface{}) *Record {
+	r := &Record{fields: make(map[string]MetaField), values: values}
+	if r.values == nil {
+		r.values = make(map[string]interface{})
+	}
+	for _, f := range fields {
+		r.fields[f.Key()] = f
+	}
+	return r
+}
+
+// Keys returns the sorted keys of the fields in the record.
+func (r *Record) Keys() []string {
+	keys := make([]string, 0, len(r.values))
+	for k := range r.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Field returns the metadata of a field, if known.
+func (r *Record) Field(key string) (MetaField, bool) {
+	f, ok := r.fields[key]
+	return f, ok
+}
+
+// Name returns the display name of a field, or its key if it has no metadata.
+func (r *Record) Name(key string) string {
+	if f, ok := r.fields[key]; ok && f.Name != "" {
+		return f.Name
+	}
+	return key
+}
+
+// Raw returns a field's value as decoded from JSON.
+func (r *Record) Raw(key string) interface{} {
+	return r.values[key]
+}
+
+func (r *Record) Set(key string, value interface{}) {
+	r.values[key] = value
+}
+
+func (r *Record) String(key string) string {
+	switch v := r.values[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		return strings.Join(r.List(key), ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Date parses a date or timestamp field, taking dates to be in local time.
+// Empty dates, including BambooHR's 0000-00-00, are returned as the zero time.
+func (r *Record) Date(key string) (time.Time, error) {
+	s := r.String(key)
+	if s == "" || s == "0000-00-00" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation(dateFormat, s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, errors.Wrap(err, key)
+}
+
+// Currency parses a currency field such as "65000.00 USD".
+func (r *Record) Currency(key string) (Currency, error) {
+	parts := strings.Fields(r.String(key))
+	switch len(parts) {
+	case 0:
+		return Currency{}, nil
+	case 1:
+		return Currency{Value: parts[0]}, nil
+	case 2:
+		return Currency{Value: parts[0], Currency: parts[1]}, nil
+	}
+	return Currency{}, errors.Errorf("%s: invalid currency %q", key, r.String(key))
+}
+
+func (r *Record) Bool(key string) (bool, error) {
+	switch v := r.values[key].(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	}
+	switch strings.ToLower(r.String(key)) {
+	case "", "false", "no", "0":
+		return false, nil
+	case "true", "yes", "1":
+		return true, nil
+	}
+	return false, errors.Errorf("%s: invalid boolean %q", key, r.String(key))
+}
+
+func (r *Record) Int(key string) (int, error) {
+	s := r.String(key)
+	if s == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(s)
+	return i, errors.Wrap(err, key)
+}
+
+// List returns the values of a multi-valued field, which BambooHR returns
+// either as an array or as a comma separated string.
+func (r *Record) List(key string) []string {
+	var list []string
+	switch v := r.values[key].(type) {
+	case nil:
+	case []interface{}:
+		for _, item := range v {
+			list = append(list, fmt.Sprint(item))
+		}
+	default:
+		for _, item := range strings.Split(r.String(key), ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// Format returns a field's value formatted for display according to its type.
+func (r *Record) Format(key string) string {
+	switch r.fields[key].Type {
+	case "date":
+		if t, err := r.Date(key); err == nil {
+			if t.IsZero() {
+				return ""
+			}
+			return t.Format(dateFormat)
+		}
+	case "timestamp":
+		if t, err := r.Date(key); err == nil && !t.IsZero() {
+			return t.Local().Format("2006-01-02 15:04")
+		}
+	case "currency":
+		if c, err := r.Currency(key); err == nil {
+			return c.String()
+		}
+	case "bool":
+		if b, err := r.Bool(key); err == nil && r.String(key) != "" {
+			if b {
+				return "Yes"
+			}
+			return "No"
+		}
+	}
+	return r.String(key)
+}
+
+// Match reports whether the formatted value of a field matches re.
+func (r *Record) Match(key string, re *regexp.Regexp) bool {
+	return re.MatchString(r.Format(key))
+}
+
+func (r *Record) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.values)
+}
+
+func (r *Record) UnmarshalJSON(b []byte) error {
+	if r.fields == nil {
+		r.fields = make(map[string]MetaField)
+	}
+	return json.Unmarshal(b, &r.values)
+}
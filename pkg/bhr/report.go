@@ -0,0 +1,261 @@
+package bhr
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Report is the result of a custom or saved report: the requested fields for
+// every matching employee.
+type Report struct {
+	Title     string
+	Fields    []MetaField
+	Employees []*Record
+}
+
+// ReportFilter restricts a report to employees whose field compares to a
+// value: = matches a case insensitive regex, and < and > compare numbers, or
+// dates and other strings. Employees without a value never pass < or >.
+type ReportFilter struct {
+	Key   string
+	Op    string
+	Value string
+
+	re *regexp.Regexp
+}
+
+type ReportCmd struct {
+	Client   *Client
+	Fields   []string
+	Filters  []ReportFilter
+	ReportID int
+	Format   string
+}
+
+// ParseReportFilter parses a filter such as lastChanged>7d,
+// lastChanged>2026-10-01 or department=eng.
+func ParseReportFilter(s string) (ReportFilter, error) {
+	i := strings.IndexAny(s, "=<>")
+	if i < 1 {
+		return ReportFilter{}, errors.Errorf("invalid filter %q, expected field=regex, field<value or field>value", s)
+	}
+	f := ReportFilter{Key: s[:i], Op: s[i : i+1], Value: s[i+1:]}
+	if f.Op == "=" {
+		var err error
+		if f.re, err = regexp.Compile("(?i)" + f.Value); err != nil {
+			return ReportFilter{}, err
+		}
+	}
+	return f, nil
+}
+
+// Match reports whether a record passes the filter.
+func (f ReportFilter) Match(r *Record) bool {
+	if f.Op == "=" {
+		re := f.re
+		if re == nil {
+			re = regexp.MustCompile("(?i)" + f.Value)
+		}
+		return r.Match(f.Key, re)
+	}
+	value := r.Format(f.Key)
+	if value == "" {
+		return false
+	}
+	cmp := strings.Compare(value, f.Value)
+	a, errA := strconv.ParseFloat(value, 64)
+	b, errB := strconv.ParseFloat(f.Value, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case a < b:
+			cmp = -1
+		case a > b:
+			cmp = 1
+		default:
+			cmp = 0
+		}
+	}
+	switch f.Op {
+	case "<":
+		return cmp < 0
+	case ">":
+		return cmp > 0
+	}
+	return false
+}
+
+// reportResponse is a report as BambooHR returns it.
+type reportResponse struct {
+	Title     string                   `json:"title"`
+	Fields    []MetaField              `json:"fields"`
+	Employees []map[string]interface{} `json:"employees"`
+}
+
+func (r *reportResponse) report() *Report {
+	report := &Report{Title: r.Title, Fields: r.Fields}
+	for _, values := range r.Employees {
+		report.Employees = append(report.Employees, NewRecord(r.Fields, values))
+	}
+	return report
+}
+
+// CustomReport returns the given fields for every employee matching the
+// filters. A lastChanged> filter is applied by BambooHR and the others are
+// applied to the results.
+func (c *Client) CustomReport(fields []string, filters []ReportFilter) (*Report, error) {
+	request := struct {
+		Title   string                 `json:"title"`
+		Filters map[string]interface{} `json:"filters,omitempty"`
+		Fields  []string               `json:"fields"`
+	}{Title: "bhr", Fields: fields}
+
+	requested := make(map[string]bool)
+	for _, key := range fields {
+		requested[key] = true
+	}
+	var local []ReportFilter
+	for _, f := range filters {
+		if f.Key == "lastChanged" && f.Op == ">" {
+			since, err := ParseSince(f.Value, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			request.Filters = map[string]interface{}{
+				"lastChanged": map[string]string{"includeNull": "no", "value": since.UTC().Format(time.RFC3339)},
+			}
+			continue
+		}
+		local = append(local, f)
+		if !requested[f.Key] {
+			request.Fields = append(request.Fields, f.Key)
+		}
+	}
+
+	var response reportResponse
+	if _, err := c.sendJSON("POST", c.baseURL+"/reports/custom?format=JSON", request, &response); err != nil {
+		return nil, err
+	}
+	report := response.report()
+	report.Filter(local)
+	// Drop the fields that were only requested for filtering.
+	var columns []MetaField
+	for _, f := range report.Fields {
+		if requested[f.Key()] {
+			columns = append(columns, f)
+		}
+	}
+	report.Fields = columns
+	return report, nil
+}
+
+// SavedReport runs a report saved in BambooHR.
+func (c *Client) SavedReport(id int) (*Report, error) {
+	var response reportResponse
+	err := c.getJSON(fmt.Sprintf(c.baseURL+"/reports/%d?format=JSON&fd=yes", id), &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.report(), nil
+}
+
+// Filter removes the employees that don't pass every filter.
+func (r *Report) Filter(filters []ReportFilter) {
+	if len(filters) == 0 {
+		return
+	}
+	var employees []*Record
+	for _, e := range r.Employees {
+		match := true
+		for _, f := range filters {
+			match = match && f.Match(e)
+		}
+		if match {
+			employees = append(employees, e)
+		}
+	}
+	r.Employees = employees
+}
+
+// RenderReport writes a report as an aligned table, CSV or JSON.
+func RenderReport(report *Report, format string, w io.Writer) error {
+	var header []string
+	for _, f := range report.Fields {
+		header = append(header, f.Name)
+	}
+	rows := make([][]string, 0, len(report.Employees))
+	for _, e := range report.Employees {
+		var row []string
+		for _, f := range report.Fields {
+			row = append(row, e.Format(f.Key()))
+		}
+		rows = append(rows, row)
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		return enc.Encode(report.Employees)
+	case "csv":
+		out := csv.NewWriter(w)
+		out.Write(header)
+		out.WriteAll(rows)
+		return out.Error()
+	case "", "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+	return errors.Errorf("unknown format %q", format)
+}
+
+func (c *ReportCmd) Run() error {
+	var report *Report
+	var err error
+	if c.ReportID != 0 {
+		report, err = c.Client.SavedReport(c.ReportID)
+		if err == nil {
+			report.Filter(c.Filters)
+		}
+	} else {
+		var meta []MetaField
+		meta, err = c.Client.Fields()
+		if err != nil {
+			return err
+		}
+		var fields []MetaField
+		fields, err = LookupFields(meta, c.Fields)
+		if err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(fields))
+		for _, f := range fields {
+			keys = append(keys, f.Key())
+		}
+		// Filters may name fields, such as lastChanged, that aren't in the
+		// field metadata, so those are passed through as given.
+		for i, filter := range c.Filters {
+			if f, err := LookupFields(meta, []string{filter.Key}); err == nil {
+				c.Filters[i].Key = f[0].Key()
+			}
+		}
+		report, err = c.Client.CustomReport(keys, c.Filters)
+	}
+	if err != nil {
+		return err
+	}
+	return RenderReport(report, c.Format, os.Stdout)
+}
@@ -0,0 +1,129 @@
+package bhr_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestReportFilterMatch(t *testing.T) {
+	fields := []bhr.MetaField{
+		{ID: "1", Name: "Department", Type: "list", Alias: "department"},
+		{ID: "2", Name: "Hire date", Type: "date", Alias: "hireDate"},
+		{ID: "3", Name: "Level", Type: "int", Alias: "level"},
+	}
+	record := bhr.NewRecord(fields, map[string]interface{}{
+		"department": "Engineering",
+		"hireDate":   "2020-06-01",
+		"level":      "9",
+	})
+	empty := bhr.NewRecord(fields, map[string]interface{}{})
+
+	tests := []struct {
+		filter string
+		record *bhr.Record
+		want   bool
+	}{
+		{"department=^eng", record, true},
+		{"department=sales", record, false},
+		{"hireDate<2021-01-01", record, true},
+		{"hireDate>2021-01-01", record, false},
+		{"level>10", record, false},
+		{"level<10", record, true},
+		{"hireDate<2021-01-01", empty, false},
+		{"hireDate>2000-01-01", empty, false},
+	}
+	for _, tt := range tests {
+		f, err := bhr.ParseReportFilter(tt.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := f.Match(tt.record); got != tt.want {
+			t.Errorf("%s: Match = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestSavedReport(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	report, err := s.Client().SavedReport(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Title != "Headcount" || len(report.Fields) != 3 || len(report.Employees) != 2 {
+		t.Fatalf("SavedReport(7) = %+v", report)
+	}
+	if got := report.Employees[1].Format("hireDate"); got != "" {
+		t.Errorf("hire date 0000-00-00 formatted as %q, want empty", got)
+	}
+	if r := s.Requests()[0]; r.Path != "reports/7" || r.Query != "format=JSON&fd=yes" {
+		t.Errorf("request %+v, want reports/7?format=JSON&fd=yes", r)
+	}
+}
+
+func TestReportCustom(t *testing.T) {
+	dir := copyFixtures(t)
+	defer os.RemoveAll(dir)
+	response := `{
+		"title": "bhr",
+		"fields": [
+			{"id": "jobTitle", "type": "list", "name": "Job title"},
+			{"id": "4001", "type": "list", "name": "Shirt size"},
+			{"id": "department", "type": "list", "name": "Department"}
+		],
+		"employees": [
+			{"id": "2", "jobTitle": "Chief Technology Officer", "4001": "L", "department": "Engineering"},
+			{"id": "4", "jobTitle": "Sales Lead", "4001": "M", "department": "Sales"}
+		]
+	}`
+	if err := os.MkdirAll(filepath.Join(dir, "reports"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "reports", "custom.post.json"), []byte(response), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := bhrtest.NewServer(dir)
+	defer s.Close()
+
+	var filters []bhr.ReportFilter
+	for _, filter := range []string{"Department=eng", "lastChanged>2026-10-01T00:00:00Z"} {
+		f, err := bhr.ParseReportFilter(filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		filters = append(filters, f)
+	}
+	c := bhr.ReportCmd{Client: s.Client(), Fields: []string{"Job title", "Shirt size"}, Filters: filters, Format: "csv"}
+	out := captureStdout(t, c.Run)
+	if want := "Job title,Shirt size\nChief Technology Officer,L\n"; out != want {
+		t.Errorf("report\n%s\nwant\n%s", out, want)
+	}
+
+	var request struct {
+		Fields  []string                     `json:"fields"`
+		Filters map[string]map[string]string `json:"filters"`
+	}
+	var body []byte
+	for _, r := range s.Requests() {
+		if r.Method == "POST" && r.Path == "reports/custom" {
+			body = r.Body
+		}
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("%v: %s", err, body)
+	}
+	if got := strings.Join(request.Fields, ","); got != "jobTitle,4001,department" {
+		t.Errorf("requested fields %s, want jobTitle,4001,department", got)
+	}
+	if got := request.Filters["lastChanged"]; got["value"] != "2026-10-01T00:00:00Z" || got["includeNull"] != "no" {
+		t.Errorf("lastChanged filter %v", got)
+	}
+}
@@ -0,0 +1,132 @@
+package bhr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrOffline is returned for requests made by a client reading from a
+// snapshot.
+var ErrOffline = errors.New("not available offline")
+
+// Snapshot is a copy of the directory, and optionally every employee's
+// record, that a client can read from instead of the API.
+type Snapshot struct {
+	Taken     time.Time                         `json:"taken"`
+	OwnerID   string                            `json:"ownerId,omitempty"`
+	Directory json.RawMessage                   `json:"directory"`
+	Fields    []MetaField                       `json:"fields,omitempty"`
+	Employees map[string]map[string]interface{} `json:"employees,omitempty"`
+}
+
+type SnapshotCmd struct {
+	Client    *Client
+	Output    string
+	Store     string
+	Employees bool
+	GetAllOptions
+}
+
+// WithSnapshot makes the client read the directory and employees from s
+// rather than the API. Anything not in the snapshot fails with ErrOffline.
+func WithSnapshot(s *Snapshot) Option {
+	return func(c *Client) {
+		c.snapshot = s
+	}
+}
+
+// TakeSnapshot copies the directory and, if employees is true, every
+// employee's record.
+func (c *Client) TakeSnapshot(employees bool, opts GetAllOptions) (*Snapshot, error) {
+	s := &Snapshot{Taken: time.Now().UTC()}
+	if err := c.getJSON(c.baseURL+"/employees/directory", &s.Directory); err != nil {
+		return nil, err
+	}
+	owner, err := c.GetEmployeeFields(0, []string{"id"})
+	if err != nil {
+		return nil, err
+	}
+	s.OwnerID = owner.String("id")
+	if s.Fields, err = c.Fields(); err != nil {
+		return nil, err
+	}
+	if !employees {
+		return s, nil
+	}
+	// A snapshot of only the active employees is still useful offline.
+	all, _, err := c.GetAllEmployees(opts)
+	if err != nil {
+		return nil, err
+	}
+	s.Employees = make(map[string]map[string]interface{})
+	for _, e := range all {
+		s.Employees[e.ID] = e.Record.values
+	}
+	return s, nil
+}
+
+func LoadSnapshot(path string) (*Snapshot, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	return s, nil
+}
+
+func (s *Snapshot) Save(path string) error {
+	b, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// Dir returns the directory in the snapshot, linking each employee accepted by
+// f to their supervisor.
+func (s *Snapshot) Dir(f FilterFunc) (*Directory, error) {
+	return NewDirectory(s.Directory, f)
+}
+
+// employee returns the values of an employee's fields, where ID 0 is the
+// owner of the API key that took the snapshot.
+func (s *Snapshot) employee(id int) (map[string]interface{}, error) {
+	key := strconv.Itoa(id)
+	if id == 0 {
+		key = s.OwnerID
+	}
+	values, ok := s.Employees[key]
+	if !ok {
+		return nil, errors.Wrapf(ErrOffline, "employee %d is not in the snapshot", id)
+	}
+	return values, nil
+}
+
+func (c *SnapshotCmd) Run() error {
+	s, err := c.Client.TakeSnapshot(c.Employees, c.GetAllOptions)
+	if err != nil {
+		return err
+	}
+	if c.Store != "" {
+		if c.Output, err = s.SaveToStore(c.Store); err != nil {
+			return err
+		}
+	} else if err := s.Save(c.Output); err != nil {
+		return err
+	}
+	dir, err := s.Dir(func(Employee) bool { return true })
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Saved %d employees to %s\n", len(dir.Employees), c.Output)
+	return nil
+}
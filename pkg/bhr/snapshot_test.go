@@ -0,0 +1,46 @@
+package bhr_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestSnapshotOffline(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	snapshot, err := s.Client().TakeSnapshot(true, bhr.GetAllOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "bhr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "dir.json")
+	if err := snapshot.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	snapshot, err = bhr.LoadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := bhr.NewClient("", bhr.WithSnapshot(snapshot))
+	if e := c.FindEmployeeByName("Dan"); e == nil || e.ID != "4" {
+		t.Errorf("FindEmployeeByName(Dan) = %+v, want ID 4", e)
+	}
+	if e := c.GetEmployee(0); e.DisplayName != "Alice Smith" || e.Record.Format("4001") != "M" {
+		t.Errorf("GetEmployee(0) = %+v, want Alice Smith", e)
+	}
+	if _, err := c.GetTable(2, "jobInfo"); err == nil {
+		t.Error("GetTable succeeded offline")
+	}
+}
@@ -0,0 +1,239 @@
+package bhr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Count is the number of employees with a department, division, location or
+// other attribute.
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Span is a manager's span of control.
+type Span struct {
+	Manager string `json:"manager"`
+	Direct  int    `json:"direct"`
+	Total   int    `json:"total"`
+}
+
+// Stats summarises the headcount and shape of the organisation.
+type Stats struct {
+	Headcount     int     `json:"headcount"`
+	ByDepartment  []Count `json:"byDepartment"`
+	ByDivision    []Count `json:"byDivision"`
+	ByLocation    []Count `json:"byLocation"`
+	Spans         []Span  `json:"spans"`
+	AverageTenure float64 `json:"averageTenureYears"`
+	MaxDepth      int     `json:"maxDepth"`
+	AverageDepth  float64 `json:"averageDepth"`
+	HiresByMonth  []Count `json:"hiresByMonth"`
+}
+
+// hiringMonths is how many months of hires stats include.
+const hiringMonths = 24
+
+type StatsCmd struct {
+	Client *Client
+	Format string
+	Chart  bool
+	Filters
+}
+
+// counts returns the number of employees per key, largest first.
+func counts(employees []*Employee, key func(*Employee) string) []Count {
+	byName := make(map[string]int)
+	for _, emp := range employees {
+		byName[key(emp)]++
+	}
+	result := make([]Count, 0, len(byName))
+	for name, n := range byName {
+		result = append(result, Count{Name: orNone(name), Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// NewStats calculates stats for the employees in a directory accepted by f,
+// with tenure measured to now from the hire dates keyed by employee ID.
+func NewStats(dir *Directory, f FilterFunc, hireDates map[string]time.Time, now time.Time) *Stats {
+	var employees []*Employee
+	for i, emp := range dir.Employees {
+		if f(emp) {
+			employees = append(employees, &dir.Employees[i])
+		}
+	}
+	stats := &Stats{
+		Headcount:    len(employees),
+		ByDepartment: counts(employees, func(e *Employee) string { return e.Department }),
+		ByDivision:   counts(employees, func(e *Employee) string { return e.Division }),
+		ByLocation:   counts(employees, func(e *Employee) string { return e.Location }),
+	}
+
+	var tenure time.Duration
+	var hired, depths int
+	var hires []time.Time
+	for _, emp := range employees {
+		if len(emp.children) > 0 {
+			stats.Spans = append(stats.Spans, Span{
+				Manager: emp.DisplayName,
+				Direct:  len(emp.children),
+				Total:   len(subtree(emp)) - 1,
+			})
+		}
+		depth := 1
+		for p := emp.parent; p != nil; p = p.parent {
+			depth++
+		}
+		depths += depth
+		if depth > stats.MaxDepth {
+			stats.MaxDepth = depth
+		}
+		if date, ok := hireDates[emp.ID]; ok && !date.IsZero() && date.Before(now) {
+			tenure += now.Sub(date)
+			hired++
+			hires = append(hires, date)
+		}
+	}
+	sort.SliceStable(stats.Spans, func(i, j int) bool {
+		if stats.Spans[i].Total != stats.Spans[j].Total {
+			return stats.Spans[i].Total > stats.Spans[j].Total
+		}
+		return stats.Spans[i].Direct > stats.Spans[j].Direct
+	})
+	stats.HiresByMonth = monthlyCounts(hires, now.AddDate(0, 1-hiringMonths, 0), now)
+	if len(employees) > 0 {
+		stats.AverageDepth = float64(depths) / float64(len(employees))
+	}
+	if hired > 0 {
+		stats.AverageTenure = tenure.Hours() / 24 / 365.25 / float64(hired)
+	}
+	return stats
+}
+
+// RenderStats writes stats as tables, or as JSON.
+func RenderStats(stats *Stats, format string, w io.Writer) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		return enc.Encode(stats)
+	case "", "table":
+	default:
+		return errors.Errorf("unknown format %q", format)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Headcount\t%d\n", stats.Headcount)
+	fmt.Fprintf(tw, "Average tenure\t%.1f years\n", stats.AverageTenure)
+	fmt.Fprintf(tw, "Org depth\t%d (average %.1f)\n", stats.MaxDepth, stats.AverageDepth)
+	sections := []struct {
+		title  string
+		counts []Count
+	}{
+		{"Department", stats.ByDepartment},
+		{"Division", stats.ByDivision},
+		{"Location", stats.ByLocation},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(tw, "\n%s\tHeadcount\n", section.title)
+		for _, c := range section.counts {
+			fmt.Fprintf(tw, "%s\t%d\n", c.Name, c.Count)
+		}
+	}
+	if len(stats.Spans) > 0 {
+		fmt.Fprintln(tw, "\n"+strings.Join([]string{"Manager", "Direct", "Total"}, "\t"))
+		for _, span := range stats.Spans {
+			fmt.Fprintf(tw, "%s\t%d\t%d\n", span.Manager, span.Direct, span.Total)
+		}
+	}
+	return tw.Flush()
+}
+
+// RenderStatsChart writes stats as bar charts and a sparkline of hires.
+func RenderStatsChart(stats *Stats, chart Chart, s *strings.Builder) {
+	s.WriteString(fmt.Sprintf("Headcount %d, average tenure %.1f years, org depth %d\n\n",
+		stats.Headcount, stats.AverageTenure, stats.MaxDepth))
+	chart.Trend("Hires by month", stats.HiresByMonth, s)
+	sections := []struct {
+		title  string
+		counts []Count
+	}{
+		{"Department", stats.ByDepartment},
+		{"Division", stats.ByDivision},
+		{"Location", stats.ByLocation},
+	}
+	for _, section := range sections {
+		s.WriteRune('\n')
+		chart.Bars(section.title, section.counts, s)
+	}
+	if len(stats.Spans) > 0 {
+		spans := make([]Count, len(stats.Spans))
+		for i, span := range stats.Spans {
+			spans[i] = Count{Name: span.Manager, Count: span.Total}
+		}
+		s.WriteRune('\n')
+		chart.Bars("Total reports", spans, s)
+	}
+}
+
+func (c *StatsCmd) Run() error {
+	f := Filter(c.Filters)
+	dir := c.Client.GetDirectory(f)
+	hireDates, err := c.Client.hireDates()
+	if err != nil {
+		return err
+	}
+
+	stats := NewStats(dir, f, hireDates, time.Now())
+	var result strings.Builder
+	if c.Chart {
+		RenderStatsChart(stats, NewChart(), &result)
+	} else if err := RenderStats(stats, c.Format, &result); err != nil {
+		return err
+	}
+	fmt.Print(result.String())
+	return nil
+}
+
+// hireDates returns the hire date of every employee by ID, from a report of
+// just those fields, or offline from the snapshot.
+func (c *Client) hireDates() (map[string]time.Time, error) {
+	hireDates := make(map[string]time.Time)
+	report, err := c.CustomReport([]string{"id", "hireDate"}, nil)
+	if errors.Cause(err) == ErrOffline {
+		employees, _, err := c.GetAllEmployees(GetAllOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range employees {
+			if date, err := e.Record.Date("hireDate"); err == nil {
+				hireDates[e.ID] = date
+			}
+		}
+		return hireDates, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range report.Employees {
+		if date, err := r.Date("hireDate"); err == nil {
+			hireDates[r.String("id")] = date
+		}
+	}
+	return hireDates, nil
+}
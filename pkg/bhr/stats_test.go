@@ -0,0 +1,49 @@
+package bhr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+func TestNewStats(t *testing.T) {
+	dir := mustDirectory(t, `{"employees": [
+		{"id": "1", "displayName": "Alice", "department": "Exec", "location": "Sydney"},
+		{"id": "2", "displayName": "Bob", "department": "Eng", "location": "Sydney", "supervisor": "Alice"},
+		{"id": "3", "displayName": "Carol", "department": "Eng", "location": "Melbourne", "supervisor": "Bob"},
+		{"id": "4", "displayName": "Dan", "department": "Sales", "location": "London", "supervisor": "Alice"}
+	]}`)
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local)
+	hireDates := map[string]time.Time{
+		"1": now.AddDate(-4, 0, 0),
+		"2": now.AddDate(-2, 0, 0),
+	}
+	stats := bhr.NewStats(dir, func(bhr.Employee) bool { return true }, hireDates, now)
+
+	if stats.Headcount != 4 || stats.MaxDepth != 3 {
+		t.Errorf("headcount %d, depth %d, want 4, 3", stats.Headcount, stats.MaxDepth)
+	}
+	if got := stats.ByDepartment[0]; got.Name != "Eng" || got.Count != 2 {
+		t.Errorf("largest department = %+v, want Eng 2", got)
+	}
+	if got := stats.ByDivision[0]; got.Name != "(none)" || got.Count != 4 {
+		t.Errorf("division = %+v, want (none) 4", got)
+	}
+	want := []bhr.Span{{Manager: "Alice", Direct: 2, Total: 3}, {Manager: "Bob", Direct: 1, Total: 1}}
+	if len(stats.Spans) != 2 || stats.Spans[0] != want[0] || stats.Spans[1] != want[1] {
+		t.Errorf("spans = %+v, want %+v", stats.Spans, want)
+	}
+	if stats.AverageTenure < 2.9 || stats.AverageTenure > 3.1 {
+		t.Errorf("average tenure = %.2f, want 3", stats.AverageTenure)
+	}
+
+	var result strings.Builder
+	if err := bhr.RenderStats(stats, "table", &result); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result.String(), "Alice    2       3") {
+		t.Errorf("missing span of control in\n%s", result.String())
+	}
+}
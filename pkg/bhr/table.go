@@ -0,0 +1,241 @@
+package bhr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+)
+
+// TableRow is a row of one of an employee's tables, such as a position in
+// their job history.
+type TableRow interface {
+	Columns() []string
+	Values() []string
+}
+
+type JobInfo struct {
+	ID         string `json:"id"`
+	EmployeeID string `json:"employeeId"`
+	Date       string `json:"date"`
+	Location   string `json:"location"`
+	Department string `json:"department"`
+	Division   string `json:"division"`
+	JobTitle   string `json:"jobTitle"`
+	ReportsTo  string `json:"reportsTo"`
+}
+
+type EmploymentStatus struct {
+	ID                       string `json:"id"`
+	EmployeeID               string `json:"employeeId"`
+	Date                     string `json:"date"`
+	EmploymentStatus         string `json:"employmentStatus"`
+	Comment                  string `json:"comment"`
+	TerminationReasonID      string `json:"terminationReasonId"`
+	TerminationTypeID        string `json:"terminationTypeId"`
+	TerminationRehireID      string `json:"terminationRehireId"`
+	TerminationRegrettableID string `json:"terminationRegrettableId"`
+}
+
+// Currency is an amount of money as BambooHR returns it.
+type Currency struct {
+	Value    string `json:"value"`
+	Currency string `json:"currency"`
+}
+
+func (c Currency) String() string {
+	if c.Value == "" {
+		return ""
+	}
+	return strings.TrimSpace(c.Value + " " + c.Currency)
+}
+
+type Compensation struct {
+	ID          string   `json:"id"`
+	EmployeeID  string   `json:"employeeId"`
+	StartDate   string   `json:"startDate"`
+	Rate        Currency `json:"rate"`
+	Type        string   `json:"type"`
+	Exempt      string   `json:"exempt"`
+	Reason      string   `json:"reason"`
+	Comment     string   `json:"comment"`
+	PaidPer     string   `json:"paidPer"`
+	PaySchedule string   `json:"paySchedule"`
+}
+
+type EmergencyContact struct {
+	ID                 string `json:"id"`
+	EmployeeID         string `json:"employeeId"`
+	Name               string `json:"name"`
+	Relationship       string `json:"relationship"`
+	HomePhone          string `json:"homePhone"`
+	MobilePhone        string `json:"mobilePhone"`
+	WorkPhone          string `json:"workPhone"`
+	WorkPhoneExtension string `json:"workPhoneExtension"`
+	Email              string `json:"email"`
+	AddressLine1       string `json:"addressLine1"`
+	AddressLine2       string `json:"addressLine2"`
+	City               string `json:"city"`
+	State              string `json:"state"`
+	Zipcode            string `json:"zipcode"`
+	Country            string `json:"country"`
+}
+
+// GenericRow is a row of a table without a dedicated type.
+type GenericRow map[string]interface{}
+
+func (r *JobInfo) Columns() []string {
+	return []string{"Date", "Job title", "Department", "Division", "Location", "Reports to"}
+}
+
+func (r *JobInfo) Values() []string {
+	return []string{r.Date, r.JobTitle, r.Department, r.Division, r.Location, r.ReportsTo}
+}
+
+func (r *EmploymentStatus) Columns() []string {
+	return []string{"Date", "Status", "Comment"}
+}
+
+func (r *EmploymentStatus) Values() []string {
+	return []string{r.Date, r.EmploymentStatus, r.Comment}
+}
+
+func (r *Compensation) Columns() []string {
+	return []string{"Start date", "Rate", "Per", "Type", "Exempt", "Reason", "Comment"}
+}
+
+func (r *Compensation) Values() []string {
+	return []string{r.StartDate, r.Rate.String(), r.PaidPer, r.Type, r.Exempt, r.Reason, r.Comment}
+}
+
+func (r *EmergencyContact) Columns() []string {
+	return []string{"Name", "Relationship", "Mobile", "Home", "Work", "Email"}
+}
+
+func (r *EmergencyContact) Values() []string {
+	return []string{r.Name, r.Relationship, r.MobilePhone, r.HomePhone, r.WorkPhone, r.Email}
+}
+
+func (r GenericRow) Columns() []string {
+	var columns []string
+	for k := range r {
+		if k == "id" || k == "employeeId" {
+			continue
+		}
+		columns = append(columns, k)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func (r GenericRow) Values() []string {
+	return r.valuesOf(r.Columns())
+}
+
+// valuesOf returns the values of the given columns, empty for the columns the
+// row doesn't have.
+func (r GenericRow) valuesOf(columns []string) []string {
+	var values []string
+	for _, k := range columns {
+		if r[k] == nil {
+			values = append(values, "")
+			continue
+		}
+		values = append(values, fmt.Sprint(r[k]))
+	}
+	return values
+}
+
+// tableRows maps the tables with dedicated types to a constructor for a row.
+var tableRows = map[string]func() TableRow{
+	"jobInfo":           func() TableRow { return &JobInfo{} },
+	"employmentStatus":  func() TableRow { return &EmploymentStatus{} },
+	"compensation":      func() TableRow { return &Compensation{} },
+	"emergencyContacts": func() TableRow { return &EmergencyContact{} },
+}
+
+type TableCmd struct {
+	Client *Client
+	Table  string
+	EmployeeFilters
+}
+
+// GetTable returns the rows of one of an employee's tables. Rows of the known
+// tables are *JobInfo, *EmploymentStatus, *Compensation or *EmergencyContact,
+// and rows of any other table are a GenericRow.
+func (c *Client) GetTable(employeeID int, tableName string) ([]TableRow, error) {
+	var raw []json.RawMessage
+	err := c.getJSON(fmt.Sprintf(c.baseURL+"/employees/%d/tables/%s", employeeID, url.PathEscape(tableName)), &raw)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]TableRow, 0, len(raw))
+	for _, r := range raw {
+		var row TableRow = &GenericRow{}
+		if newRow, ok := tableRows[tableName]; ok {
+			row = newRow()
+		}
+		if err := json.Unmarshal(r, row); err != nil {
+			return nil, errors.Wrap(err, tableName)
+		}
+		if generic, ok := row.(*GenericRow); ok {
+			row = *generic
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (c *TableCmd) Run() error {
+	id, err := c.EmployeeID(c.Client)
+	if err != nil {
+		return err
+	}
+	rows, err := c.Client.GetTable(id, c.Table)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Printf("No %s rows\n", c.Table)
+		return nil
+	}
+	return RenderTable(rows, os.Stdout)
+}
+
+// RenderTable writes rows as an aligned table. Generic rows may have
+// different fields, so their columns are all the fields of any row.
+func RenderTable(rows []TableRow, out io.Writer) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	columns := rows[0].Columns()
+	if _, ok := rows[0].(GenericRow); ok {
+		seen := make(map[string]bool)
+		columns = nil
+		for _, row := range rows {
+			for _, column := range row.Columns() {
+				if !seen[column] {
+					seen[column] = true
+					columns = append(columns, column)
+				}
+			}
+		}
+		sort.Strings(columns)
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+	for _, row := range rows {
+		values := row.Values()
+		if generic, ok := row.(GenericRow); ok {
+			values = generic.valuesOf(columns)
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,58 @@
+package bhr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestGetTable(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+	c := s.Client()
+
+	rows, err := c.GetTable(2, "jobInfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d jobInfo rows, want 2", len(rows))
+	}
+	job, ok := rows[0].(*bhr.JobInfo)
+	if !ok || job.JobTitle != "Chief Technology Officer" || job.ReportsTo != "Alice Smith" {
+		t.Errorf("rows[0] = %#v, want the CTO *JobInfo", rows[0])
+	}
+
+	rows, err = c.GetTable(2, "certifications")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rows[0].(bhr.GenericRow); !ok || len(rows) != 2 {
+		t.Fatalf("certifications rows = %#v, want 2 GenericRows", rows)
+	}
+	var out strings.Builder
+	if err := bhr.RenderTable(rows, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := "expires     name                     provider\n" +
+		"2027-03-01  AWS Solutions Architect  \n" +
+		"            First aid                Red Cross\n"
+	if out.String() != want {
+		t.Errorf("RenderTable wrote\n%q\nwant\n%q", out.String(), want)
+	}
+}
+
+func TestRenderTableJobInfo(t *testing.T) {
+	rows := []bhr.TableRow{&bhr.JobInfo{Date: "2019-01-01", JobTitle: "CTO", Department: "Engineering"}}
+	var out strings.Builder
+	if err := bhr.RenderTable(rows, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := "Date        Job title  Department   Division  Location  Reports to\n" +
+		"2019-01-01  CTO        Engineering                      \n"
+	if out.String() != want {
+		t.Errorf("RenderTable wrote\n%q\nwant\n%q", out.String(), want)
+	}
+}
@@ -0,0 +1,425 @@
+package bhr
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const browserHelp = "↑↓ move  ←→ collapse  / search  n next  m manager  r reports  y copy email  q quit"
+
+type TUICmd struct {
+	Client *Client
+	Filters
+}
+
+// Browser is the state of the interactive directory browser: a tree of
+// employees with collapsible managers, a selection and a search.
+type Browser struct {
+	roots     []*Employee
+	collapsed map[*Employee]bool
+	rows      []browserRow
+	cursor    int
+	offset    int
+	searching bool
+	search    string
+	status    string
+	copy      func(string)
+}
+
+type browserRow struct {
+	emp   *Employee
+	level int
+}
+
+// NewBrowser returns a browser over the employees in dir accepted by f, with
+// everyone below the second level collapsed. copy is called to copy an email
+// address to the clipboard.
+func NewBrowser(dir *Directory, f FilterFunc, copy func(string)) *Browser {
+	b := &Browser{collapsed: make(map[*Employee]bool), copy: copy}
+	for i, emp := range dir.Employees {
+		if f(emp) && emp.parent == nil {
+			b.roots = append(b.roots, &dir.Employees[i])
+		}
+	}
+	for i, emp := range dir.Employees {
+		if emp.parent != nil && len(emp.children) > 0 {
+			b.collapsed[&dir.Employees[i]] = true
+		}
+	}
+	b.refresh()
+	return b
+}
+
+// refresh rebuilds the visible rows, keeping the selection.
+func (b *Browser) refresh() {
+	selected := b.Selected()
+	b.rows = b.rows[:0]
+	var walk func(emp *Employee, level int)
+	walk = func(emp *Employee, level int) {
+		b.rows = append(b.rows, browserRow{emp, level})
+		if b.collapsed[emp] {
+			return
+		}
+		for _, child := range emp.children {
+			walk(child, level+1)
+		}
+	}
+	for _, root := range b.roots {
+		walk(root, 0)
+	}
+	b.cursor = 0
+	b.selectEmployee(selected)
+}
+
+// Selected returns the selected employee, if any.
+func (b *Browser) Selected() *Employee {
+	if b.cursor < 0 || b.cursor >= len(b.rows) {
+		return nil
+	}
+	return b.rows[b.cursor].emp
+}
+
+// selectEmployee selects emp, expanding their managers if need be.
+func (b *Browser) selectEmployee(emp *Employee) {
+	if emp == nil {
+		return
+	}
+	for i, row := range b.rows {
+		if row.emp == emp {
+			b.cursor = i
+			return
+		}
+	}
+	hidden := false
+	for p := emp.parent; p != nil; p = p.parent {
+		if b.collapsed[p] {
+			b.collapsed[p] = false
+			hidden = true
+		}
+	}
+	if hidden {
+		b.refresh()
+		b.selectEmployee(emp)
+	}
+}
+
+// all returns every employee in tree order, collapsed or not.
+func (b *Browser) all() []*Employee {
+	var employees []*Employee
+	for _, root := range b.roots {
+		employees = append(employees, subtree(root)...)
+	}
+	return employees
+}
+
+// find selects the next employee, starting from the selected one if from is
+// 0, whose name, title, department or email matches the search.
+func (b *Browser) find(from int) {
+	re, err := regexp.Compile("(?i)" + regexp.QuoteMeta(b.search))
+	if err != nil || b.search == "" {
+		return
+	}
+	employees := b.all()
+	start := 0
+	for i, emp := range employees {
+		if emp == b.Selected() {
+			start = i
+		}
+	}
+	for i := range employees {
+		emp := employees[(start+from+i)%len(employees)]
+		if re.MatchString(strings.Join([]string{emp.DisplayName, emp.JobTitle, emp.Department, emp.WorkEmail}, "\n")) {
+			b.selectEmployee(emp)
+			b.status = ""
+			return
+		}
+	}
+	b.status = fmt.Sprintf("No match for %q", b.search)
+}
+
+func (b *Browser) move(n int) {
+	b.cursor += n
+	if b.cursor >= len(b.rows) {
+		b.cursor = len(b.rows) - 1
+	}
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+}
+
+// Key handles a key press, named as by readKey, and reports whether the
+// browser should quit.
+func (b *Browser) Key(key string) bool {
+	if b.searching {
+		switch key {
+		case "ctrl-c":
+			return true
+		case "up", "down":
+			// Leave the search where it is, so n still finds the next match.
+			b.searching = false
+			if key == "up" {
+				b.move(-1)
+			} else {
+				b.move(1)
+			}
+		case "enter":
+			b.searching = false
+		case "esc":
+			b.searching, b.search = false, ""
+		case "backspace":
+			if b.search != "" {
+				_, size := utf8.DecodeLastRuneInString(b.search)
+				b.search = b.search[:len(b.search)-size]
+			}
+		default:
+			if utf8.RuneCountInString(key) == 1 {
+				b.search += key
+				b.find(0)
+			}
+		}
+		return false
+	}
+
+	b.status = ""
+	emp := b.Selected()
+	switch key {
+	case "q", "ctrl-c":
+		return true
+	case "up", "k":
+		b.move(-1)
+	case "down", "j":
+		b.move(1)
+	case "pgup":
+		b.move(-10)
+	case "pgdn":
+		b.move(10)
+	case "home", "g":
+		b.cursor = 0
+	case "end", "G":
+		b.cursor = len(b.rows) - 1
+	case "left", "h":
+		if emp != nil && len(emp.children) > 0 && !b.collapsed[emp] {
+			b.collapsed[emp] = true
+			b.refresh()
+		} else if emp != nil && emp.parent != nil {
+			b.selectEmployee(emp.parent)
+		}
+	case "right", "l":
+		if emp != nil && b.collapsed[emp] {
+			b.collapsed[emp] = false
+			b.refresh()
+		}
+	case "enter", " ":
+		if emp != nil && len(emp.children) > 0 {
+			b.collapsed[emp] = !b.collapsed[emp]
+			b.refresh()
+		}
+	case "/":
+		b.searching, b.search = true, ""
+	case "n":
+		b.find(1)
+	case "m":
+		if emp != nil && emp.parent != nil {
+			b.selectEmployee(emp.parent)
+		} else {
+			b.status = "No manager"
+		}
+	case "r":
+		if emp != nil && len(emp.children) > 0 {
+			b.selectEmployee(emp.children[0])
+		} else {
+			b.status = "No reports"
+		}
+	case "y":
+		if emp != nil && emp.WorkEmail != "" {
+			b.copy(emp.WorkEmail)
+			b.status = "Copied " + emp.WorkEmail
+		} else {
+			b.status = "No email"
+		}
+	}
+	return false
+}
+
+// fit pads or truncates s to width runes.
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		return string([]rune(s)[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-n)
+}
+
+// details returns the lines of the detail pane for emp.
+func details(emp *Employee) []string {
+	var s strings.Builder
+	IRender(&IndividualEmployee{
+		ID:          emp.ID,
+		DisplayName: emp.DisplayName,
+		JobTitle:    emp.JobTitle,
+		WorkEmail:   emp.WorkEmail,
+		WorkPhone:   emp.WorkPhone,
+		Department:  emp.Department,
+		Supervisor:  emp.Supervisor,
+		Location:    emp.Location,
+	}, &s)
+	if emp.Division != "" {
+		s.WriteString(fmt.Sprintf("%-*s%s\n", 15, "Division: ", emp.Division))
+	}
+	if len(emp.children) > 0 {
+		s.WriteString(fmt.Sprintf("%-*s%d direct, %d total\n", 15, "Reports: ", len(emp.children), len(subtree(emp))-1))
+		for _, child := range emp.children {
+			s.WriteString("  " + child.DisplayName + "\n")
+		}
+	}
+	return strings.Split(strings.TrimSuffix(s.String(), "\n"), "\n")
+}
+
+// View renders the browser to fit a screen of the given size: the tree on
+// the left, details of the selected employee on the right and a status line.
+func (b *Browser) View(width, height int) string {
+	listHeight := height - 1
+	if listHeight < 1 {
+		listHeight = 1
+	}
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	}
+	if b.cursor >= b.offset+listHeight {
+		b.offset = b.cursor - listHeight + 1
+	}
+	treeWidth := width / 2
+	var detail []string
+	if emp := b.Selected(); emp != nil {
+		detail = details(emp)
+	}
+
+	var s strings.Builder
+	for i := 0; i < listHeight; i++ {
+		line := ""
+		if row := b.offset + i; row < len(b.rows) {
+			emp := b.rows[row].emp
+			marker := "  "
+			if len(emp.children) > 0 {
+				marker = "▾ "
+				if b.collapsed[emp] {
+					marker = "▸ "
+				}
+			}
+			line = strings.Repeat("  ", b.rows[row].level) + marker + fmt.Sprintf("%s (%s)", emp.DisplayName, emp.JobTitle)
+			line = fit(line, treeWidth-1)
+			if row == b.cursor {
+				line = "\x1b[7m" + line + "\x1b[0m"
+			}
+		} else {
+			line = fit(line, treeWidth-1)
+		}
+		s.WriteString(line + " │ ")
+		if i < len(detail) {
+			s.WriteString(fit(detail[i], width-treeWidth-2))
+		}
+		s.WriteString("\x1b[K\r\n")
+	}
+	switch {
+	case b.searching:
+		s.WriteString("/" + b.search)
+	case b.status != "":
+		s.WriteString(fit(b.status, width))
+	default:
+		s.WriteString(fit(browserHelp, width))
+	}
+	s.WriteString("\x1b[K")
+	return s.String()
+}
+
+// readKey reads a key press from a terminal in raw mode and names it, e.g.
+// "a", "enter", "up" or "ctrl-c".
+func readKey(r *bufio.Reader) (string, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return "", err
+	}
+	switch c {
+	case 3:
+		return "ctrl-c", nil
+	case '\r', '\n':
+		return "enter", nil
+	case 127, 8:
+		return "backspace", nil
+	case 27:
+		if r.Buffered() == 0 {
+			return "esc", nil
+		}
+		seq := make([]byte, 0, 4)
+		for r.Buffered() > 0 {
+			b, err := r.ReadByte()
+			if err != nil {
+				return "", err
+			}
+			seq = append(seq, b)
+			if b >= 'A' && b <= 'Z' || b == '~' {
+				break
+			}
+		}
+		keys := map[string]string{
+			"[A": "up", "[B": "down", "[C": "right", "[D": "left",
+			"[H": "home", "[F": "end", "[1~": "home", "[4~": "end",
+			"[5~": "pgup", "[6~": "pgdn", "OA": "up", "OB": "down", "OC": "right", "OD": "left",
+		}
+		return keys[string(seq)], nil
+	}
+	return string(c), nil
+}
+
+// copyToClipboard asks the terminal to copy s with an OSC 52 escape sequence,
+// which works over SSH too.
+func copyToClipboard(w io.Writer) func(string) {
+	return func(s string) {
+		fmt.Fprintf(w, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(s)))
+	}
+}
+
+func (c *TUICmd) Run() error {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) || !terminal.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("bhr tui needs a terminal")
+	}
+	f := Filter(c.Filters)
+	browser := NewBrowser(c.Client.GetDirectory(f), f, copyToClipboard(os.Stdout))
+
+	state, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer terminal.Restore(fd, state)
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+	in := bufio.NewReader(os.Stdin)
+	for {
+		width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			return err
+		}
+		fmt.Print("\x1b[H" + browser.View(width, height))
+		key, err := readKey(in)
+		if err != nil {
+			return err
+		}
+		if browser.Key(key) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package bhr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+func TestBrowser(t *testing.T) {
+	dir := mustDirectory(t, `{"employees": [
+		{"id": "1", "displayName": "Alice", "jobTitle": "CEO"},
+		{"id": "2", "displayName": "Bob", "jobTitle": "CTO", "supervisor": "Alice", "workEmail": "bob@example.com"},
+		{"id": "3", "displayName": "Carol", "jobTitle": "Engineer", "supervisor": "Bob"},
+		{"id": "4", "displayName": "Dan", "jobTitle": "Sales", "supervisor": "Alice"}
+	]}`)
+	var copied string
+	b := bhr.NewBrowser(dir, func(bhr.Employee) bool { return true }, func(s string) { copied = s })
+
+	press := func(keys ...string) string {
+		for _, key := range keys {
+			b.Key(key)
+		}
+		return b.Selected().DisplayName
+	}
+	if got := press("down", "down"); got != "Dan" {
+		t.Errorf("Carol should start collapsed under Bob, selected %s", got)
+	}
+	if got := press("/", "c", "a", "r", "enter"); got != "Carol" {
+		t.Errorf("search selected %s, want Carol", got)
+	}
+	if got := press("m"); got != "Bob" {
+		t.Errorf("manager selected %s, want Bob", got)
+	}
+	if got := press("y"); copied != "bob@example.com" {
+		t.Errorf("copied %q from %s, want bob@example.com", copied, got)
+	}
+	if got := press("left", "down"); got != "Dan" {
+		t.Errorf("collapsing Bob then moving down selected %s, want Dan", got)
+	}
+	if got := press("m", "r"); got != "Bob" {
+		t.Errorf("first report of Alice is %s, want Bob", got)
+	}
+
+	view := b.View(80, 6)
+	if !strings.Contains(view, "▸ Bob (CTO)") || !strings.Contains(view, "Reports:") {
+		t.Errorf("unexpected view\n%s", view)
+	}
+	if !b.Key("q") {
+		t.Error("q should quit")
+	}
+}
+
+func TestBrowserSearchKeys(t *testing.T) {
+	dir := mustDirectory(t, `{"employees": [
+		{"id": "1", "displayName": "Alice", "jobTitle": "CEO"},
+		{"id": "2", "displayName": "Bob", "jobTitle": "CTO", "supervisor": "Alice"},
+		{"id": "3", "displayName": "Dan", "jobTitle": "Sales", "supervisor": "Alice"}
+	]}`)
+	b := bhr.NewBrowser(dir, func(bhr.Employee) bool { return true }, func(string) {})
+
+	for _, key := range []string{"/", "b", "o", "down"} {
+		b.Key(key)
+	}
+	if got := b.Selected().DisplayName; got != "Dan" {
+		t.Errorf("down while searching selected %s, want Dan", got)
+	}
+	// The search has ended, so keys are commands again.
+	if b.Key("k"); b.Selected().DisplayName != "Bob" {
+		t.Errorf("k after search selected %s, want Bob", b.Selected().DisplayName)
+	}
+	b.Key("/")
+	if b.Key("up") {
+		t.Error("up while searching should not quit")
+	}
+	b.Key("/")
+	if !b.Key("ctrl-c") {
+		t.Error("ctrl-c while searching should quit")
+	}
+}
@@ -0,0 +1,271 @@
+package bhr
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// readOnlyFields are documented as read only and can't be updated.
+var readOnlyFields = map[string]bool{
+	"id":                      true,
+	"age":                     true,
+	"birthday":                true,
+	"displayName":             true,
+	"fullName1":               true,
+	"fullName2":               true,
+	"fullName3":               true,
+	"fullName4":               true,
+	"fullName5":               true,
+	"lastChanged":             true,
+	"stateCode":               true,
+	"supervisor":              true,
+	"supervisorId":            true,
+	"supervisorEmail":         true,
+	"supervisorEId":           true,
+	"employmentHistoryStatus": true,
+	"terminationDate":         true,
+	"workPhonePlusExtension":  true,
+	"isPhotoUploaded":         true,
+	"photoUploaded":           true,
+}
+
+// Change is a change to one field of an employee.
+type Change struct {
+	Field MetaField
+	Old   string
+	New   string
+}
+
+type SetCmd struct {
+	Client      *Client
+	Assignments []string
+	Yes         bool
+	EmployeeFilters
+}
+
+// ParseAssignments parses field=value arguments.
+func ParseAssignments(assignments []string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, a := range assignments {
+		i := strings.Index(a, "=")
+		if i < 1 {
+			return nil, errors.Errorf("invalid assignment %q, expected field=value", a)
+		}
+		values[a[:i]] = a[i+1:]
+	}
+	return values, nil
+}
+
+// ValidateFields checks new values against the field metadata. It returns the
+// values keyed by field key (see MetaField.Key), with list values normalized
+// to the option's name, along with the fields they belong to.
+func (c *Client) ValidateFields(values map[string]string) (map[string]string, []MetaField, error) {
+	meta, err := c.Fields()
+	if err != nil {
+		return nil, nil, err
+	}
+	lists, err := c.Lists()
+	if err != nil {
+		return nil, nil, err
+	}
+	listByKey := make(map[string]ListField)
+	for _, l := range lists {
+		listByKey[l.Key()] = l
+	}
+
+	validated := make(map[string]string)
+	var fields []MetaField
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := values[name]
+		found, err := LookupFields(meta, []string{name})
+		if err != nil {
+			return nil, nil, err
+		}
+		f := found[0]
+		if readOnlyFields[f.Key()] {
+			return nil, nil, errors.Errorf("%s is read only", f.Key())
+		}
+		if value, err = validateValue(f, listByKey, value); err != nil {
+			return nil, nil, errors.Wrap(err, f.Key())
+		}
+		validated[f.Key()] = value
+		fields = append(fields, f)
+	}
+	return validated, fields, nil
+}
+
+func validateValue(f MetaField, lists map[string]ListField, value string) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+	switch f.Type {
+	case "date":
+		if _, err := time.Parse(dateFormat, value); err != nil {
+			return "", errors.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+		}
+	case "int", "integer":
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", errors.Errorf("invalid integer %q", value)
+		}
+	case "bool":
+		r := NewRecord(nil, map[string]interface{}{"v": value})
+		if _, err := r.Bool("v"); err != nil {
+			return "", errors.Errorf("invalid boolean %q", value)
+		}
+	case "currency":
+		r := NewRecord(nil, map[string]interface{}{"v": value})
+		cur, err := r.Currency("v")
+		if err == nil {
+			_, err = strconv.ParseFloat(cur.Value, 64)
+		}
+		if err != nil {
+			return "", errors.Errorf("invalid currency %q, expected e.g. 65000.00 USD", value)
+		}
+	case "email":
+		if !strings.Contains(value, "@") {
+			return "", errors.Errorf("invalid email %q", value)
+		}
+	}
+	if l, ok := lists[f.Key()]; ok && len(l.Options) > 0 {
+		o, ok := l.Option(value)
+		if !ok {
+			return "", errors.Errorf("%q is not an option of %s", value, l.Name)
+		}
+		value = o.Name
+	}
+	return value, nil
+}
+
+// ownerID returns id, or if it is 0, the real ID of the API key owner it
+// stands for.
+func (c *Client) ownerID(id int) (int, error) {
+	if id != 0 {
+		return id, nil
+	}
+	owner, err := c.GetEmployeeFields(0, []string{"id"})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(owner.String("id"))
+}
+
+// UpdateEmployee sets fields of an employee, keyed by field key.
+func (c *Client) UpdateEmployee(id int, fields map[string]string) error {
+	_, err := c.sendJSON("POST", fmt.Sprintf(c.baseURL+"/employees/%d", id), fields, nil)
+	return err
+}
+
+// Changes compares new values against the current record, returning only the
+// fields whose value differs once both are normalized (see normalizeValue).
+func Changes(fields []MetaField, current *Record, values map[string]string) []Change {
+	var changes []Change
+	for _, f := range fields {
+		old := current.String(f.Key())
+		if normalizeValue(f, old) == normalizeValue(f, values[f.Key()]) {
+			continue
+		}
+		changes = append(changes, Change{Field: f, Old: old, New: values[f.Key()]})
+	}
+	return changes
+}
+
+// normalizeValue returns a value of a field in a form for comparing, so that
+// equivalent values such as 0000-00-00 and no date, or 65000.00 USD and
+// 65000 USD, are the same.
+func normalizeValue(f MetaField, value string) string {
+	r := NewRecord([]MetaField{f}, map[string]interface{}{f.Key(): value})
+	switch f.Type {
+	case "currency":
+		if cur, err := r.Currency(f.Key()); err == nil {
+			if v, err := strconv.ParseFloat(cur.Value, 64); err == nil {
+				return strconv.FormatFloat(v, 'f', 2, 64) + " " + strings.ToUpper(cur.Currency)
+			}
+		}
+	case "int", "integer":
+		if i, err := r.Int(f.Key()); err == nil && value != "" {
+			return strconv.Itoa(i)
+		}
+	}
+	return r.Format(f.Key())
+}
+
+func RenderChanges(changes []Change, s *strings.Builder) {
+	pad := 0
+	for _, change := range changes {
+		if len(change.Field.Name)+2 > pad {
+			pad = len(change.Field.Name) + 2
+		}
+	}
+	for _, change := range changes {
+		s.WriteString(fmt.Sprintf("%-*s%s -> %s\n", pad, change.Field.Name+": ", orNone(change.Old), orNone(change.New)))
+	}
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func (c *SetCmd) Run() error {
+	values, err := ParseAssignments(c.Assignments)
+	if err != nil {
+		return err
+	}
+	id, err := c.EmployeeID(c.Client)
+	if err != nil {
+		return err
+	}
+	// ID 0 reads the API key owner but can't be written to.
+	if id, err = c.Client.ownerID(id); err != nil {
+		return err
+	}
+	values, fields, err := c.Client.ValidateFields(values)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.Key())
+	}
+	current, err := c.Client.GetEmployeeFields(id, keys)
+	if err != nil {
+		return err
+	}
+
+	changes := Changes(fields, current, values)
+	if len(changes) == 0 {
+		fmt.Println("No changes")
+		return nil
+	}
+	var result strings.Builder
+	RenderChanges(changes, &result)
+	fmt.Print(result.String())
+	if !c.Yes && !confirm("Apply these changes?") {
+		return errors.New("aborted")
+	}
+
+	update := make(map[string]string)
+	for _, change := range changes {
+		update[change.Field.Key()] = change.New
+	}
+	if err := c.Client.UpdateEmployee(id, update); err != nil {
+		return err
+	}
+	fmt.Printf("Updated %d field(s)\n", len(update))
+	return nil
+}
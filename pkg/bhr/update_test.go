@@ -0,0 +1,109 @@
+package bhr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestValidateFields(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+	c := s.Client()
+
+	tests := []struct {
+		name, value string
+		key, want   string
+		wantErr     bool
+	}{
+		{"hireDate", "2026-10-01", "hireDate", "2026-10-01", false},
+		{"hireDate", "2026-13-01", "", "", true},
+		{"Hire date", "", "hireDate", "", false},
+		{"displayName", "Al", "", "", true},
+		{"terminationDate", "2026-10-01", "", "", true},
+		{"Desk number", "12", "4002", "12", false},
+		{"4002", "twelve", "", "", true},
+		{"includeInPayroll", "yes", "includeInPayroll", "yes", false},
+		{"includeInPayroll", "maybe", "", "", true},
+		{"department", "engineering", "department", "Engineering", false},
+		{"department", "Marketing", "", "", true},
+		{"payRate", "65000 USD", "payRate", "65000 USD", false},
+		{"payRate", "lots", "", "", true},
+		{"workEmail", "alice", "", "", true},
+		{"nonsense", "x", "", "", true},
+	}
+	for _, tt := range tests {
+		values, fields, err := c.ValidateFields(map[string]string{tt.name: tt.value})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s=%s: error %v, want error %v", tt.name, tt.value, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(fields) != 1 || fields[0].Key() != tt.key || values[tt.key] != tt.want {
+			t.Errorf("%s=%s: got %v %+v, want %s=%s", tt.name, tt.value, values, fields, tt.key, tt.want)
+		}
+	}
+}
+
+func TestChanges(t *testing.T) {
+	fields := []bhr.MetaField{
+		{ID: "11", Name: "Date of birth", Type: "date", Alias: "dateOfBirth"},
+		{ID: "12", Name: "Pay rate", Type: "currency", Alias: "payRate"},
+		{ID: "4", Name: "Department", Type: "list", Alias: "department"},
+		{ID: "4002", Name: "Desk number", Type: "int"},
+		{ID: "13", Name: "Include in payroll", Type: "bool", Alias: "includeInPayroll"},
+	}
+	current := bhr.NewRecord(fields, map[string]interface{}{
+		"dateOfBirth":      "0000-00-00",
+		"payRate":          "65000.00 USD",
+		"department":       "Sales",
+		"4002":             "007",
+		"includeInPayroll": "true",
+	})
+	changes := bhr.Changes(fields, current, map[string]string{
+		"dateOfBirth":      "",
+		"payRate":          "65000 USD",
+		"department":       "Engineering",
+		"4002":             "7",
+		"includeInPayroll": "yes",
+	})
+	if len(changes) != 1 || changes[0].Field.Key() != "department" ||
+		changes[0].Old != "Sales" || changes[0].New != "Engineering" {
+		t.Errorf("Changes = %+v, want just department Sales -> Engineering", changes)
+	}
+}
+
+func TestUpdateEmployee(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	if err := s.Client().UpdateEmployee(2, map[string]string{"department": "Sales"}); err != nil {
+		t.Fatal(err)
+	}
+	requests := s.Requests()
+	if len(requests) != 1 || requests[0].Method != "POST" || requests[0].Path != "employees/2" {
+		t.Fatalf("Requests() = %+v, want POST employees/2", requests)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(requests[0].Body, &body); err != nil || len(body) != 1 || body["department"] != "Sales" {
+		t.Errorf("body %s, want {\"department\":\"Sales\"}", requests[0].Body)
+	}
+}
+
+func TestSetOwner(t *testing.T) {
+	s := bhrtest.NewServer(fixtures)
+	defer s.Close()
+
+	// Without --id or --name the change goes to the API key owner's real ID.
+	c := bhr.SetCmd{Client: s.Client(), Assignments: []string{"department=Sales"}, Yes: true,
+		EmployeeFilters: bhr.EmployeeFilters{ID: -1}}
+	captureStdout(t, c.Run)
+	requests := s.Requests()
+	if last := requests[len(requests)-1]; last.Method != "POST" || last.Path != "employees/1" {
+		t.Errorf("last request %+v, want POST employees/1", last)
+	}
+}
@@ -0,0 +1,50 @@
+package bhrtest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Recorder is an http.RoundTripper that saves successful GET responses as
+// fixtures a Server can replay. Recorded fixtures contain real employee data,
+// so review them before committing them.
+type Recorder struct {
+	Dir       string
+	Transport http.RoundTripper
+}
+
+// NewRecorder returns a recorder saving fixtures in dir, sending requests with
+// t, or http.DefaultTransport if t is nil. Use it with bhr.WithTransport.
+func NewRecorder(dir string, t http.RoundTripper) *Recorder {
+	if t == nil {
+		t = http.DefaultTransport
+	}
+	return &Recorder{Dir: dir, Transport: t}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := r.Transport.RoundTrip(req)
+	if err != nil || req.Method != "GET" || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	file := FixturePath(req.URL.Path)
+	if path.Ext(file) == "" {
+		file += fixtureExt(res.Header.Get("Content-Type"))
+	}
+	file = filepath.Join(r.Dir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return nil, err
+	}
+	return res, ioutil.WriteFile(file, body, 0644)
+}
@@ -0,0 +1,174 @@
+// Package bhrtest provides a fake BambooHR server for testing, serving
+// fixture files, and a transport that records real responses as fixtures.
+package bhrtest
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shric/bhr/pkg/bhr"
+)
+
+// apiPrefix is the path of the API below the server URL, for the company
+// "test".
+const apiPrefix = "/api/gateway.php/test/v1"
+
+// serverPlaceholder is replaced in fixtures by the URL of the server, so that
+// fixtures can refer to other fixtures, e.g. in photo URLs.
+const serverPlaceholder = "{{server}}"
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// Server is a fake BambooHR API serving fixtures from a directory. A request
+// for employees/directory is served from employees/directory.json, or if that
+// doesn't exist another file with the same base name, such as
+// employees/1/photo/large.jpg. A request for a path with an extension, such as
+// photos/1-2.jpg, is served from the file of that name. Files other than JSON
+// are served with an ETag and Last-Modified date, and answer conditional
+// requests. Other methods are served from e.g. reports/custom.post.json if
+// it exists, and otherwise succeed with an empty body.
+type Server struct {
+	*httptest.Server
+	dir string
+
+	mu       sync.Mutex
+	requests []Request
+	nextID   int
+}
+
+// NewServer starts a server serving the fixtures in dir. Close it when done.
+func NewServer(dir string) *Server {
+	s := &Server{dir: dir, nextID: 1000}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL returns the URL to pass to bhr.WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + apiPrefix
+}
+
+// Client returns a client for the server.
+func (s *Server) Client(opts ...bhr.Option) *bhr.Client {
+	return bhr.NewClient("test", append([]bhr.Option{bhr.WithBaseURL(s.BaseURL())}, opts...)...)
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	p := FixturePath(r.URL.Path)
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: p, Query: r.URL.RawQuery, Body: body})
+	s.mu.Unlock()
+
+	if user, _, ok := r.BasicAuth(); !ok || user == "" {
+		http.Error(w, "missing API key", http.StatusUnauthorized)
+		return
+	}
+
+	file := p
+	if r.Method != "GET" {
+		file += "." + strings.ToLower(r.Method)
+	}
+	if path.Ext(p) == "" {
+		file += ".json"
+		if r.Method == "GET" {
+			file = s.findFixture(p)
+		}
+	}
+	name := filepath.Join(s.dir, filepath.FromSlash(file))
+	b, err := ioutil.ReadFile(name)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err) && r.Method == "GET":
+		http.NotFound(w, r)
+		return
+	case os.IsNotExist(err):
+		if r.Method == "POST" && p == "employees" {
+			s.mu.Lock()
+			s.nextID++
+			w.Header().Set("Location", fmt.Sprintf("%s/employees/%d", s.BaseURL(), s.nextID))
+			s.mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+		}
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !strings.HasSuffix(file, ".json") {
+		var modTime time.Time
+		if info, err := os.Stat(name); err == nil {
+			modTime = info.ModTime()
+		}
+		w.Header().Set("ETag", fmt.Sprintf(`"%x"`, sha1.Sum(b)))
+		http.ServeContent(w, r, file, modTime, bytes.NewReader(b))
+		return
+	}
+	b = bytes.Replace(b, []byte(serverPlaceholder), []byte(s.URL), -1)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+// findFixture returns the fixture for a GET of a path without an extension:
+// p.json, or any other file named p with an extension.
+func (s *Server) findFixture(p string) string {
+	file := p + ".json"
+	if _, err := os.Stat(filepath.Join(s.dir, filepath.FromSlash(file))); err == nil {
+		return file
+	}
+	matches, _ := filepath.Glob(filepath.Join(s.dir, filepath.FromSlash(p)) + ".*")
+	if len(matches) == 0 {
+		return file
+	}
+	return p + filepath.Ext(matches[0])
+}
+
+// fixtureExt returns the extension of a fixture file for a response of the
+// given content type.
+func fixtureExt(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "", "application/json", "text/json":
+		return ".json"
+	case "image/jpeg":
+		return ".jpg"
+	}
+	if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ".json"
+}
+
+// FixturePath returns the fixture path for a request path: the path below the
+// API version for API requests, and the path itself otherwise.
+func FixturePath(p string) string {
+	if i := strings.Index(p, "/v1/"); i != -1 && strings.HasPrefix(p, "/api/gateway.php/") {
+		p = p[i+len("/v1/"):]
+	}
+	return strings.Trim(p, "/")
+}
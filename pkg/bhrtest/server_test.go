@@ -0,0 +1,52 @@
+package bhrtest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shric/bhr/pkg/bhr"
+	"github.com/shric/bhr/pkg/bhrtest"
+)
+
+func TestRecordReplay(t *testing.T) {
+	live := bhrtest.NewServer("testdata")
+	defer live.Close()
+
+	dir, err := ioutil.TempDir("", "bhrtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	recording := live.Client(bhr.WithTransport(bhrtest.NewRecorder(dir, nil)))
+	want := recording.GetEmployee(2)
+	if _, err := os.Stat(filepath.Join(dir, "employees", "2.json")); err != nil {
+		t.Fatalf("employee not recorded: %v", err)
+	}
+
+	replay := bhrtest.NewServer(dir)
+	defer replay.Close()
+	got := replay.Client().GetEmployee(2)
+	if got.DisplayName != want.DisplayName || got.Record.Format("4001") != "L" {
+		t.Errorf("replayed %+v, want %+v", got, want)
+	}
+}
+
+func TestServerRecordsRequests(t *testing.T) {
+	s := bhrtest.NewServer("testdata")
+	defer s.Close()
+
+	id, err := s.Client().AddEmployee(map[string]string{"firstName": "Jane", "lastName": "Doe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id == 0 {
+		t.Error("AddEmployee returned ID 0")
+	}
+	requests := s.Requests()
+	if len(requests) != 1 || requests[0].Method != "POST" || requests[0].Path != "employees" {
+		t.Errorf("Requests() = %+v", requests)
+	}
+}